@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Datasource Name
+	auditLogName = "sourcehut_audit_log"
+
+	// Schema keys
+	sinceKey     = "since"
+	ipAddressKey = "ip"
+	detailsKey   = "details"
+)
+
+// dataSourceAuditLog returns a data source for reading the audit log of the
+// authenticated users account.
+func dataSourceAuditLog() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAuditLogRead,
+
+		Schema: map[string]*schema.Schema{
+			sinceKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `Only return entries created at or after this date in RFC3339
+					format (eg. '2026-01-01T00:00:00Z').`,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			eventsKey: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only return entries of these event types (eg. 'ssh_key_added').",
+			},
+			entriesKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The audit log entries, latest first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						idKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the entry.",
+						},
+						ipAddressKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address the event originated from.",
+						},
+						eventKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the event.",
+						},
+						detailsKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Details of the event, empty if there are none.",
+						},
+						createdKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date of the event in RFC3339 format.",
+						},
+						createdTimestampKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The date of the event as a unix timestamp.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAuditLogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	var since time.Time
+	if v, ok := d.GetOk(sinceKey); ok {
+		t, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		since = t
+	}
+
+	entries, err := config.client.GetAuditLog(ctx, since)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	events := d.Get(eventsKey).(*schema.Set)
+	list := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		if events.Len() > 0 && !events.Contains(entry.EventType) {
+			continue
+		}
+
+		var details string
+		if entry.Details != nil {
+			details = *entry.Details
+		}
+
+		list = append(list, map[string]interface{}{
+			idKey:               entry.ID,
+			ipAddressKey:        entry.IPAddress,
+			eventKey:            entry.EventType,
+			detailsKey:          details,
+			createdKey:          entry.Created.Format(time.RFC3339),
+			createdTimestampKey: entry.Created.Unix(),
+		})
+	}
+
+	// The audit log always belongs to the authenticated user
+	d.SetId("me")
+
+	if err := d.Set(entriesKey, list); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting entries key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Datasource Name
+	gitUserWebhooksName = "sourcehut_git_user_webhooks"
+
+	// Schema keys
+	webhooksKey = "webhooks"
+)
+
+// dataSourceGitUserWebhooks returns a data source for listing the user
+// webhooks of git.sr.ht of the authenticated user.
+func dataSourceGitUserWebhooks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGitUserWebhooksRead,
+
+		Schema: map[string]*schema.Schema{
+			webhooksKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The user webhooks, ordered by ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						idKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the webhook.",
+						},
+						urlKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL the webhook payload is sent to.",
+						},
+						eventsKey: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The events that trigger the webhook.",
+						},
+						queryKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The GraphQL query that is run to build the webhook payload.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGitUserWebhooksRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	hooks, err := config.client.GetGitUserWebhooks(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sort.Slice(hooks, func(i, j int) bool {
+		return hooks[i].ID < hooks[j].ID
+	})
+
+	list := make([]map[string]interface{}, 0, len(hooks))
+	for _, hook := range hooks {
+		list = append(list, map[string]interface{}{
+			idKey:     hook.ID,
+			urlKey:    hook.URL,
+			eventsKey: hook.Events,
+			queryKey:  hook.Query,
+		})
+	}
+
+	// User webhooks always belong to the authenticated user
+	d.SetId("me")
+
+	if err := d.Set(webhooksKey, list); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting webhooks key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceHgRepo returns a data source for getting information about a
+// mercurial repository.
+func dataSourceHgRepo() *schema.Resource {
+	return &schema.Resource{
+		Read:   resourceHgRepoRead,
+		Schema: hgRepoSchema(),
+	}
+}
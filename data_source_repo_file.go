@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Datasource Name
+	repoFileName = "sourcehut_repository_file"
+
+	// Schema keys
+	repoKey          = "repository"
+	pathKey          = "path"
+	contentBase64Key = "content_base64"
+	binaryKey        = "binary"
+	objectIDKey      = "object_id"
+	commitIDKey      = "commit_id"
+)
+
+// repoOwnerSchema returns the schema of the optional owner of a repository
+// that is looked up by name.
+func repoOwnerSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: `The name of the user that owns the repository (eg. 'example' or
+			'~example'). Defaults to the authenticated user.`,
+	}
+}
+
+// dataSourceRepoFile returns a data source for reading a file from a git
+// repository at a given revision.
+func dataSourceRepoFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepoFileRead,
+
+		Schema: map[string]*schema.Schema{
+			ownerKey: repoOwnerSchema(),
+			repoKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			pathKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the file in the repository.",
+			},
+			revspecKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "HEAD",
+				Description: "The revision to read the file from, eg. a branch, tag or commit ID.",
+			},
+			contentsKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The files contents as a UTF-8 encoded string. Empty for binary files.",
+			},
+			contentBase64Key: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The files contents base64 encoded. Only set for binary files.",
+			},
+			binaryKey: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the file is a binary file.",
+			},
+			sizeKey: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the file in bytes.",
+			},
+			objectIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the git blob object.",
+			},
+			commitIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the commit the revision resolved to.",
+			},
+		},
+	}
+}
+
+func dataSourceRepoFileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	repo := d.Get(repoKey).(string)
+	path := d.Get(pathKey).(string)
+
+	entry, commitID, err := config.client.GetRepositoryPath(ctx,
+		strings.TrimPrefix(d.Get(ownerKey).(string), "~"), repo,
+		d.Get(revspecKey).(string), path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if entry == nil {
+		return diag.Errorf("file %s not found in repository %s", path, repo)
+	}
+
+	if entry.Object.Type != "BLOB" {
+		return diag.Errorf("%s in repository %s is not a file", path, repo)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", commitID, path))
+
+	if err := d.Set(contentsKey, entry.Object.Text); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting contents key: %s", err))
+	}
+
+	if err := d.Set(contentBase64Key, entry.Object.Base64); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting content base64 key: %s", err))
+	}
+
+	if err := d.Set(binaryKey, entry.Object.IsBinary()); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting binary key: %s", err))
+	}
+
+	if err := d.Set(sizeKey, entry.Object.Size()); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting size key: %s", err))
+	}
+
+	if err := d.Set(objectIDKey, entry.Object.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting object id key: %s", err))
+	}
+
+	if err := d.Set(commitIDKey, commitID); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting commit id key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
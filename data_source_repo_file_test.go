@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceRepoFileRead(t *testing.T) {
+	binary := []byte{0x00, 0xff, 0x10, 0x80, 0x7f}
+
+	tests := []struct {
+		name     string
+		object   map[string]interface{}
+		contents string
+		base64   string
+		binary   bool
+		size     int
+	}{
+		{
+			name: "text",
+			object: map[string]interface{}{
+				"__typename": "TextBlob",
+				"type":       "BLOB",
+				"id":         "b1",
+				"shortId":    "b1",
+				"text":       "hello\n",
+			},
+			contents: "hello\n",
+			size:     6,
+		},
+		{
+			name: "binary",
+			object: map[string]interface{}{
+				"__typename": "BinaryBlob",
+				"type":       "BLOB",
+				"id":         "b1",
+				"shortId":    "b1",
+				"base64":     base64.StdEncoding.EncodeToString(binary),
+			},
+			base64: base64.StdEncoding.EncodeToString(binary),
+			binary: true,
+			size:   len(binary),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig(t, func(host, query string, vars map[string]interface{}) interface{} {
+				if strings.Contains(query, "size") {
+					t.Error("Expected no size field, git.sr.ht blobs don't have one")
+				}
+				if vars["username"] != "example" || vars["repo"] != "repo" || vars["path"] != "README" {
+					t.Errorf("Unexpected variables %v", vars)
+				}
+				return map[string]interface{}{
+					"user": map[string]interface{}{
+						"repository": map[string]interface{}{
+							"commit": map[string]interface{}{"id": "c1"},
+							"path": map[string]interface{}{
+								"id":     "b1",
+								"name":   "README",
+								"mode":   0o100644,
+								"object": tt.object,
+							},
+						},
+					},
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceRepoFile().Schema, map[string]interface{}{
+				ownerKey: "~example",
+				repoKey:  "repo",
+				pathKey:  "README",
+			})
+			if diags := dataSourceRepoFileRead(context.Background(), d, config); diags.HasError() {
+				t.Fatalf("Failed to read file: %v", diags)
+			}
+
+			if d.Id() != "c1:README" {
+				t.Errorf("Expected ID c1:README, got %s", d.Id())
+			}
+			if got := d.Get(contentsKey).(string); got != tt.contents {
+				t.Errorf("Expected contents %q, got %q", tt.contents, got)
+			}
+			if got := d.Get(contentBase64Key).(string); got != tt.base64 {
+				t.Errorf("Expected base64 contents %q, got %q", tt.base64, got)
+			}
+			if got := d.Get(binaryKey).(bool); got != tt.binary {
+				t.Errorf("Expected binary %t, got %t", tt.binary, got)
+			}
+			if got := d.Get(sizeKey).(int); got != tt.size {
+				t.Errorf("Expected size %d, got %d", tt.size, got)
+			}
+			if got := d.Get(commitIDKey).(string); got != "c1" {
+				t.Errorf("Expected commit ID c1, got %s", got)
+			}
+		})
+	}
+}
+
+func TestDataSourceRepoFileReadNotAFile(t *testing.T) {
+	config := testConfig(t, func(host, query string, vars map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"user": map[string]interface{}{
+				"repository": map[string]interface{}{
+					"commit": map[string]interface{}{"id": "c1"},
+					"path": map[string]interface{}{
+						"id":     "t1",
+						"name":   "docs",
+						"mode":   0o040000,
+						"object": map[string]interface{}{"__typename": "Tree", "type": "TREE", "id": "t1"},
+					},
+				},
+			},
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceRepoFile().Schema, map[string]interface{}{
+		repoKey: "repo",
+		pathKey: "docs",
+	})
+	if diags := dataSourceRepoFileRead(context.Background(), d, config); !diags.HasError() {
+		t.Error("Expected an error for a directory")
+	}
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Datasource Name
+	repoLogName = "sourcehut_repository_log"
+
+	// Schema keys
+	fromKey           = "from"
+	maxCountKey       = "max_count"
+	commitsKey        = "commits"
+	shortIDKey        = "short_id"
+	authorNameKey     = "author_name"
+	authorEmailKey    = "author_email"
+	authorTimeKey     = "author_time"
+	committerNameKey  = "committer_name"
+	committerEmailKey = "committer_email"
+	committerTimeKey  = "committer_time"
+	messageKey        = "message"
+	parentsKey        = "parents"
+)
+
+// dataSourceRepoLog returns a data source for reading the commit log of a
+// git repository.
+func dataSourceRepoLog() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepoLogRead,
+
+		Schema: map[string]*schema.Schema{
+			ownerKey: repoOwnerSchema(),
+			repoKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			fromKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The revision to start the log from, eg. a branch, tag or commit ID. Defaults to HEAD.",
+			},
+			maxCountKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				Description:  "The maximum number of commits to return.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			commitsKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The commits, latest first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						idKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the commit.",
+						},
+						shortIDKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The abbreviated ID of the commit.",
+						},
+						authorNameKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the author.",
+						},
+						authorEmailKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The email of the author.",
+						},
+						authorTimeKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date on which the commit was authored in RFC3339 format.",
+						},
+						committerNameKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the committer.",
+						},
+						committerEmailKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The email of the committer.",
+						},
+						committerTimeKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date on which the commit was committed in RFC3339 format.",
+						},
+						messageKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The commit message.",
+						},
+						parentsKey: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IDs of the parent commits.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRepoLogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	owner := strings.TrimPrefix(d.Get(ownerKey).(string), "~")
+	repo := d.Get(repoKey).(string)
+
+	commits, err := config.client.GetLog(ctx, owner, repo,
+		d.Get(fromKey).(string), d.Get(maxCountKey).(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list := make([]map[string]interface{}, 0, len(commits))
+	for _, commit := range commits {
+		parents := make([]string, 0, len(commit.Parents))
+		for _, parent := range commit.Parents {
+			parents = append(parents, parent.ID)
+		}
+
+		list = append(list, map[string]interface{}{
+			idKey:             commit.ID,
+			shortIDKey:        commit.ShortID,
+			authorNameKey:     commit.Author.Name,
+			authorEmailKey:    commit.Author.Email,
+			authorTimeKey:     commit.Author.Time.Format(time.RFC3339),
+			committerNameKey:  commit.Committer.Name,
+			committerEmailKey: commit.Committer.Email,
+			committerTimeKey:  commit.Committer.Time.Format(time.RFC3339),
+			messageKey:        commit.Message,
+			parentsKey:        parents,
+		})
+	}
+
+	id := fmt.Sprintf("%s/%s", owner, repo)
+	if len(commits) > 0 {
+		id = fmt.Sprintf("%s:%s", id, commits[0].ID)
+	}
+	d.SetId(id)
+
+	if err := d.Set(commitsKey, list); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting commits key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
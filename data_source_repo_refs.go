@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Datasource Name
+	repoRefsName = "sourcehut_repository_refs"
+
+	// Schema keys
+	prefixKey    = "prefix"
+	refsKey      = "refs"
+	shortNameKey = "short_name"
+	targetKey    = "target"
+	typeKey      = "type"
+)
+
+// dataSourceRepoRefs returns a data source for listing the branches and tags
+// of a git repository.
+func dataSourceRepoRefs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepoRefsRead,
+
+		Schema: map[string]*schema.Schema{
+			ownerKey: repoOwnerSchema(),
+			repoKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			prefixKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list references whose name starts with this prefix (eg. 'refs/tags/v').",
+			},
+			sortByKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "name",
+				Description: `The order of the references, either "name" (alphabetically) or
+					"semver" (tags by semantic version, latest first, followed by all other
+					references by name).`,
+				ValidateFunc: validation.StringInSlice([]string{"name", "semver"}, false),
+			},
+			refsKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The references matching the prefix.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						nameKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The full name of the reference (eg. 'refs/tags/v1.0.0').",
+						},
+						shortNameKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the branch or tag (eg. 'v1.0.0').",
+						},
+						targetKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the commit the reference points to.",
+						},
+						typeKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The type of the reference ("branch", "tag" or "other").`,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// refType returns the type and short name of a reference.
+func refType(name string) (string, string) {
+	switch {
+	case strings.HasPrefix(name, "refs/heads/"):
+		return "branch", strings.TrimPrefix(name, "refs/heads/")
+	case strings.HasPrefix(name, "refs/tags/"):
+		return "tag", strings.TrimPrefix(name, "refs/tags/")
+	default:
+		return "other", name
+	}
+}
+
+// sortRefsSemver orders tags that are valid semantic versions from latest to
+// oldest, followed by all other references by name.
+func sortRefsSemver(refs []client.Reference) {
+	versions := make(map[string]*version.Version, len(refs))
+	for _, ref := range refs {
+		if t, short := refType(ref.Name); t == "tag" {
+			if v, err := version.NewSemver(short); err == nil {
+				versions[ref.Name] = v
+			}
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		vi, vj := versions[refs[i].Name], versions[refs[j].Name]
+		switch {
+		case vi != nil && vj != nil:
+			return vi.GreaterThan(vj)
+		case vi != nil || vj != nil:
+			return vi != nil
+		default:
+			return refs[i].Name < refs[j].Name
+		}
+	})
+}
+
+func dataSourceRepoRefsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	owner := strings.TrimPrefix(d.Get(ownerKey).(string), "~")
+	repo := d.Get(repoKey).(string)
+	prefix := d.Get(prefixKey).(string)
+
+	refs, err := config.client.GetReferences(ctx, owner, repo)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filtered := make([]client.Reference, 0, len(refs))
+	for _, ref := range refs {
+		if strings.HasPrefix(ref.Name, prefix) {
+			filtered = append(filtered, ref)
+		}
+	}
+
+	if d.Get(sortByKey).(string) == "semver" {
+		sortRefsSemver(filtered)
+	} else {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Name < filtered[j].Name
+		})
+	}
+
+	list := make([]map[string]interface{}, 0, len(filtered))
+	for _, ref := range filtered {
+		t, short := refType(ref.Name)
+		list = append(list, map[string]interface{}{
+			nameKey:      ref.Name,
+			shortNameKey: short,
+			targetKey:    ref.Commit,
+			typeKey:      t,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s:%s", owner, repo, prefix))
+
+	if err := d.Set(refsKey, list); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting refs key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
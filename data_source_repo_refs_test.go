@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+)
+
+func TestSortRefsSemver(t *testing.T) {
+	refs := []client.Reference{
+		{Name: "refs/heads/main"},
+		{Name: "refs/tags/v1.2.0"},
+		{Name: "refs/tags/v1.10.0"},
+		{Name: "refs/tags/nightly"},
+		{Name: "refs/tags/v2.0.0-rc1"},
+		{Name: "refs/tags/v1.9.1"},
+	}
+
+	sortRefsSemver(refs)
+
+	var names []string
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+
+	expected := []string{
+		"refs/tags/v2.0.0-rc1",
+		"refs/tags/v1.10.0",
+		"refs/tags/v1.9.1",
+		"refs/tags/v1.2.0",
+		"refs/heads/main",
+		"refs/tags/nightly",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected order %v, got %v", expected, names)
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Datasource Name
+	repoTreeName = "sourcehut_repository_tree"
+
+	// Schema keys
+	recursiveKey = "recursive"
+	entriesKey   = "entries"
+)
+
+// dataSourceRepoTree returns a data source for listing a directory of a git
+// repository at a given revision.
+func dataSourceRepoTree() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRepoTreeRead,
+
+		Schema: map[string]*schema.Schema{
+			ownerKey: repoOwnerSchema(),
+			repoKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			pathKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the directory in the repository. Defaults to the root directory.",
+			},
+			revspecKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "HEAD",
+				Description: "The revision to list the directory at, eg. a branch, tag or commit ID.",
+			},
+			recursiveKey: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to list the contents of subdirectories as well.",
+			},
+			commitIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the commit the revision resolved to.",
+			},
+			entriesKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The entries of the directory.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						nameKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the entry.",
+						},
+						pathKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the entry relative to the root of the repository.",
+						},
+						modeKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The git file mode of the entry in octal notation (eg. '100644').",
+						},
+						typeKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: `The type of the entry ("blob", "tree" or "submodule").`,
+						},
+						objectIDKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the git object.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// treeEntryType returns the type of a tree entry based on its git file mode.
+func treeEntryType(mode int) string {
+	switch mode & 0o170000 {
+	case 0o040000:
+		return "tree"
+	case 0o160000:
+		return "submodule"
+	default:
+		return "blob"
+	}
+}
+
+func dataSourceRepoTreeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	owner := strings.TrimPrefix(d.Get(ownerKey).(string), "~")
+	repo := d.Get(repoKey).(string)
+	revspec := d.Get(revspecKey).(string)
+	recursive := d.Get(recursiveKey).(bool)
+	root := strings.Trim(d.Get(pathKey).(string), "/")
+
+	// The revision is resolved once, so that every directory is read from
+	// the same commit even if a branch moves during the walk
+	commitID, err := config.client.RevParse(ctx, owner, repo, revspec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list := []map[string]interface{}{}
+
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		entries, err := config.client.GetTree(ctx, owner, repo, commitID, dir)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			entryPath := path.Join(dir, entry.Name)
+			entryType := treeEntryType(entry.Mode)
+
+			list = append(list, map[string]interface{}{
+				nameKey:     entry.Name,
+				pathKey:     entryPath,
+				modeKey:     fmt.Sprintf("%06o", entry.Mode),
+				typeKey:     entryType,
+				objectIDKey: entry.ID,
+			})
+
+			if recursive && entryType == "tree" {
+				if err := walk(entryPath); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := walk(root); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s:%s:%s", owner, repo, revspec, root))
+
+	if err := d.Set(commitIDKey, commitID); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting commit id key: %s", err))
+	}
+
+	if err := d.Set(entriesKey, list); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting entries key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceRepoTreeReadResolvesOnce(t *testing.T) {
+	entries := func(results ...map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"entries": map[string]interface{}{"results": results, "cursor": nil},
+		}
+	}
+
+	var revParses int
+	config := testConfig(t, func(host, query string, vars map[string]interface{}) interface{} {
+		if strings.Contains(query, "query RevParse") {
+			revParses++
+			if vars["revspec"] != "main" {
+				t.Errorf("Expected revspec main to be resolved, got %v", vars["revspec"])
+			}
+			return map[string]interface{}{
+				"user": map[string]interface{}{
+					"repository": map[string]interface{}{
+						"commit": map[string]interface{}{"id": "c1"},
+					},
+				},
+			}
+		}
+
+		// Every directory has to be read from the resolved commit
+		if vars["revspec"] != "c1" {
+			t.Errorf("Expected the tree to be read at c1, got %v", vars["revspec"])
+		}
+
+		repository := map[string]interface{}{}
+		switch vars["path"] {
+		case nil:
+			repository["object"] = map[string]interface{}{
+				"tree": entries(
+					map[string]interface{}{"id": "b1", "name": "README", "mode": 0o100644},
+					map[string]interface{}{"id": "t1", "name": "docs", "mode": 0o040000},
+				),
+			}
+		case "docs":
+			object := entries(map[string]interface{}{"id": "b2", "name": "index.md", "mode": 0o100644})
+			object["type"] = "TREE"
+			repository["path"] = map[string]interface{}{"object": object}
+		default:
+			t.Fatalf("Unexpected path %v", vars["path"])
+		}
+
+		return map[string]interface{}{
+			"user": map[string]interface{}{"repository": repository},
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceRepoTree().Schema, map[string]interface{}{
+		repoKey:      "repo",
+		revspecKey:   "main",
+		recursiveKey: true,
+	})
+	if diags := dataSourceRepoTreeRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Failed to read tree: %v", diags)
+	}
+
+	if revParses != 1 {
+		t.Errorf("Expected the revision to be resolved once, got %d", revParses)
+	}
+	if got := d.Get(commitIDKey).(string); got != "c1" {
+		t.Errorf("Expected commit ID c1, got %s", got)
+	}
+
+	var paths []string
+	for _, entry := range d.Get(entriesKey).([]interface{}) {
+		paths = append(paths, entry.(map[string]interface{})[pathKey].(string))
+	}
+	if got := strings.Join(paths, " "); got != "README docs docs/index.md" {
+		t.Errorf("Expected paths README docs docs/index.md, got %s", got)
+	}
+}
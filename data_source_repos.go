@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Datasource Name
+	reposName = "sourcehut_repositories"
+
+	// Schema keys
+	nameRegexKey        = "name_regex"
+	sortByKey           = "sort_by"
+	reposKey            = "repositories"
+	updatedKey          = "updated"
+	updatedTimestampKey = "updated_unix"
+)
+
+// dataSourceRepos returns a data source for listing the repositories of a
+// user.
+func dataSourceRepos() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceReposRead,
+
+		Schema: map[string]*schema.Schema{
+			ownerKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `The name of the user whose repositories are listed (eg. 'example'
+					or '~example'). Defaults to the authenticated user.`,
+			},
+			nameRegexKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A regular expression the repository names have to match.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			visiKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `Only list repositories with this visibility ("public", "unlisted",
+					or "private").`,
+				ValidateFunc: validation.StringInSlice([]string{"PUBLIC", "UNLISTED", "PRIVATE"}, true),
+			},
+			sortByKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "name",
+				Description: `The order of the repositories, either "name" (alphabetically) or
+					"updated" (most recently updated first).`,
+				ValidateFunc: validation.StringInSlice([]string{"name", "updated"}, false),
+			},
+			reposKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The repositories matching the filters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						idKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the repository.",
+						},
+						nameKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the repository.",
+						},
+						descKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A description of the repository.",
+						},
+						visiKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The visibility of the repository.",
+						},
+						ownerKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The canonical name of the user that owns the repository (eg. '~example').",
+						},
+						createdKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date on which the repo was created in RFC3339 format.",
+						},
+						updatedKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date on which the repo was last updated in RFC3339 format.",
+						},
+						updatedTimestampKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The date on which the repo was last updated as a unix timestamp.",
+						},
+						webURLKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the repository in the web interface.",
+						},
+						httpsCloneURLKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL to clone the repository over HTTPS.",
+						},
+						sshCloneURLKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL to clone the repository over SSH.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReposRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	owner := strings.TrimPrefix(d.Get(ownerKey).(string), "~")
+
+	repos, err := config.client.GetRepositories(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := d.Get(nameRegexKey).(string); v != "" {
+		nameRegex = regexp.MustCompile(v)
+	}
+	visibility := strings.ToUpper(d.Get(visiKey).(string))
+
+	filtered := make([]client.Repository, 0, len(repos))
+	for _, repo := range repos {
+		if nameRegex != nil && !nameRegex.MatchString(repo.Name) {
+			continue
+		}
+		if visibility != "" && repo.Visibility != visibility {
+			continue
+		}
+		filtered = append(filtered, repo)
+	}
+
+	if d.Get(sortByKey).(string) == "updated" {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Updated.After(filtered[j].Updated)
+		})
+	} else {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Name < filtered[j].Name
+		})
+	}
+
+	list := make([]map[string]interface{}, 0, len(filtered))
+	for _, repo := range filtered {
+		web, https, ssh, err := repoURLs(config.gitURL, repo.Owner.CanonicalName, repo.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		list = append(list, map[string]interface{}{
+			idKey:               repo.ID,
+			nameKey:             repo.Name,
+			descKey:             repo.Description,
+			visiKey:             repo.Visibility,
+			ownerKey:            repo.Owner.CanonicalName,
+			createdKey:          repo.Created.Format(time.RFC3339),
+			updatedKey:          repo.Updated.Format(time.RFC3339),
+			updatedTimestampKey: repo.Updated.Unix(),
+			webURLKey:           web,
+			httpsCloneURLKey:    https,
+			sshCloneURLKey:      ssh,
+		})
+	}
+
+	if owner == "" {
+		d.SetId("me")
+	} else {
+		d.SetId("~" + owner)
+	}
+
+	if err := d.Set(reposKey, list); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting repositories key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Datasource Name
+	tagVerificationName = "sourcehut_tag_verification"
+
+	// Schema keys
+	tagKey               = "tag"
+	commitKey            = "commit"
+	armoredKeyRingKey    = "armored_keyring"
+	signedKey            = "signed"
+	validKey             = "valid"
+	signerFingerprintKey = "signer_fingerprint"
+	signerKeyIDKey       = "signer_key_id"
+	reasonKey            = "reason"
+
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	pgpPublicKeyHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+)
+
+// dataSourceTagVerification returns a data source for verifying the PGP
+// signature of an annotated tag or a commit.
+func dataSourceTagVerification() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTagVerificationRead,
+
+		Schema: map[string]*schema.Schema{
+			ownerKey: repoOwnerSchema(),
+			repoKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			tagKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The name of the annotated tag to verify (eg. 'v1.0.0').",
+				ExactlyOneOf: []string{tagKey, commitKey},
+			},
+			commitKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The revision of the commit to verify, eg. a branch or commit ID.",
+				ExactlyOneOf: []string{tagKey, commitKey},
+			},
+			armoredKeyRingKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `One or more armored PGP public keys to verify the signature
+					against. Defaults to the PGP keys the repository owner registered on
+					meta.sr.ht.`,
+				ValidateFunc: validateArmoredKeyRing,
+			},
+			objectIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the verified tag or commit object.",
+			},
+			signedKey: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the object carries a PGP signature.",
+			},
+			validKey: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the signature is valid and made by a key of the key ring.",
+			},
+			signerFingerprintKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fingerprint of the primary key that made a valid signature.",
+			},
+			signerKeyIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key ID of the primary key that made a valid signature.",
+			},
+			reasonKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Why the signature is not valid. Empty if it is.",
+			},
+		},
+	}
+}
+
+// splitTagSignature splits a raw tag object into the signed data and the
+// armored signature appended to the tag message.
+func splitTagSignature(raw string) (string, string) {
+	i := strings.Index(raw, pgpSignatureHeader)
+	if i < 0 || (i > 0 && raw[i-1] != '\n') {
+		return raw, ""
+	}
+	return raw[:i], raw[i:]
+}
+
+// splitCommitSignature splits a raw commit object into the signed data, which
+// is the commit without its gpgsig header, and the armored signature stored
+// in that header.
+func splitCommitSignature(raw string) (string, string) {
+	var data, sig strings.Builder
+	inHeaders, inSig := true, false
+	for _, line := range strings.SplitAfter(raw, "\n") {
+		if inHeaders {
+			switch {
+			case line == "\n":
+				inHeaders, inSig = false, false
+			case strings.HasPrefix(line, "gpgsig "):
+				inSig = true
+				sig.WriteString(strings.TrimPrefix(line, "gpgsig "))
+				continue
+			case inSig && strings.HasPrefix(line, " "):
+				sig.WriteString(line[1:])
+				continue
+			default:
+				inSig = false
+			}
+		}
+		data.WriteString(line)
+	}
+
+	if !strings.HasPrefix(sig.String(), pgpSignatureHeader) {
+		return raw, ""
+	}
+	return data.String(), sig.String()
+}
+
+// readArmoredKeyRing reads every armored public key block of s into a key
+// ring. It is an error if s contains no key block.
+func readArmoredKeyRing(s string) (openpgp.EntityList, error) {
+	blocks := strings.Split(s, pgpPublicKeyHeader)
+	if len(blocks) < 2 {
+		return nil, fmt.Errorf("no armored PGP public key found")
+	}
+
+	var keyring openpgp.EntityList
+	for _, block := range blocks[1:] {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(pgpPublicKeyHeader + block))
+		if err != nil {
+			return nil, fmt.Errorf("error reading armored PGP key: %w", err)
+		}
+		keyring = append(keyring, entities...)
+	}
+	return keyring, nil
+}
+
+func validateArmoredKeyRing(v interface{}, k string) ([]string, []error) {
+	if _, err := readArmoredKeyRing(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// verifySignature checks an armored detached signature over data. The
+// reason is empty if the signature is valid.
+func verifySignature(keyring openpgp.EntityList, data, sig string) (*openpgp.Entity, string) {
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring,
+		strings.NewReader(data), strings.NewReader(sig), nil)
+	switch {
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		return nil, "signed by a key that is not in the key ring"
+	case err != nil:
+		return nil, fmt.Sprintf("invalid signature: %s", err)
+	default:
+		return signer, ""
+	}
+}
+
+func dataSourceTagVerificationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	owner := strings.TrimPrefix(d.Get(ownerKey).(string), "~")
+	repo := d.Get(repoKey).(string)
+	tag := d.Get(tagKey).(string)
+
+	var objectID string
+	if tag != "" {
+		refs, err := config.client.GetReferences(ctx, owner, repo)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, ref := range refs {
+			if ref.Name == "refs/tags/"+tag {
+				objectID = ref.Target
+			}
+		}
+		if objectID == "" {
+			return diag.Errorf("tag %s not found in repository %s", tag, repo)
+		}
+	} else {
+		id, err := config.client.RevParse(ctx, owner, repo, d.Get(commitKey).(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		objectID = id
+	}
+
+	object, err := config.client.GetRawObject(ctx, owner, repo, objectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if object == nil {
+		return diag.Errorf("object %s not found in repository %s", objectID, repo)
+	}
+
+	var data, sig, reason string
+	switch {
+	case tag != "" && object.Type != "TAG":
+		reason = "lightweight tags cannot be signed"
+	case tag != "":
+		data, sig = splitTagSignature(object.Raw)
+	default:
+		data, sig = splitCommitSignature(object.Raw)
+	}
+	if reason == "" && sig == "" {
+		reason = "no PGP signature"
+	}
+
+	var signer *openpgp.Entity
+	if sig != "" {
+		armored := d.Get(armoredKeyRingKey).(string)
+		if armored == "" {
+			keys, err := config.client.GetPGPKeys(ctx, owner)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, key := range keys {
+				armored += key.Key + "\n"
+			}
+		}
+
+		// An owner without registered keys leaves the key ring empty
+		var keyring openpgp.EntityList
+		if armored != "" {
+			keyring, err = readArmoredKeyRing(armored)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		signer, reason = verifySignature(keyring, data, sig)
+	}
+
+	d.SetId(object.ID)
+
+	if err := d.Set(objectIDKey, object.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting object id key: %s", err))
+	}
+
+	if err := d.Set(signedKey, sig != ""); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting signed key: %s", err))
+	}
+
+	if err := d.Set(validKey, signer != nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting valid key: %s", err))
+	}
+
+	var fingerprint, keyID string
+	if signer != nil {
+		fingerprint = fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint)
+		keyID = signer.PrimaryKey.KeyIdString()
+	}
+
+	if err := d.Set(signerFingerprintKey, fingerprint); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting signer fingerprint key: %s", err))
+	}
+
+	if err := d.Set(signerKeyIDKey, keyID); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting signer key id key: %s", err))
+	}
+
+	if err := d.Set(reasonKey, reason); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting reason key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
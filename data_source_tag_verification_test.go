@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testSign(t *testing.T, signer *openpgp.Entity, data string) string {
+	var sig bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sig, signer, strings.NewReader(data), nil); err != nil {
+		t.Fatal(err)
+	}
+	return sig.String() + "\n"
+}
+
+func testArmoredPublicKey(t *testing.T, e *openpgp.Entity) string {
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestVerifyTagSignature(t *testing.T) {
+	signer, err := openpgp.NewEntity("Example", "", "example@example.org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := openpgp.NewEntity("Other", "", "other@example.org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag := "object 0123456789012345678901234567890123456789\n" +
+		"type commit\n" +
+		"tag v1.0.0\n" +
+		"tagger Example <example@example.org> 1761652800 +0000\n" +
+		"\n" +
+		"Release v1.0.0\n"
+	raw := tag + testSign(t, signer, tag)
+
+	data, sig := splitTagSignature(raw)
+	if data != tag {
+		t.Fatalf("Expected signed data %q, got %q", tag, data)
+	}
+
+	keyring, err := readArmoredKeyRing(testArmoredPublicKey(t, other) + testArmoredPublicKey(t, signer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keyring) != 2 {
+		t.Fatalf("Expected 2 keys in key ring, got %d", len(keyring))
+	}
+
+	entity, reason := verifySignature(keyring, data, sig)
+	if entity == nil || reason != "" {
+		t.Fatalf("Expected valid signature, got %q", reason)
+	}
+	if entity.PrimaryKey.KeyId != signer.PrimaryKey.KeyId {
+		t.Errorf("Expected signer %s, got %s", signer.PrimaryKey.KeyIdString(), entity.PrimaryKey.KeyIdString())
+	}
+
+	if entity, _ := verifySignature(keyring[:1], data, sig); entity != nil {
+		t.Error("Expected signature by a key outside the key ring to be invalid")
+	}
+
+	if entity, _ := verifySignature(keyring, strings.Replace(data, "v1.0.0", "v6.6.6", 1), sig); entity != nil {
+		t.Error("Expected signature over modified tag to be invalid")
+	}
+
+	if _, sig := splitTagSignature(tag); sig != "" {
+		t.Errorf("Expected no signature on unsigned tag, got %q", sig)
+	}
+}
+
+func TestVerifyCommitSignature(t *testing.T) {
+	signer, err := openpgp.NewEntity("Example", "", "example@example.org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := "tree 0123456789012345678901234567890123456789\n" +
+		"author Example <example@example.org> 1761652800 +0000\n" +
+		"committer Example <example@example.org> 1761652800 +0000\n"
+	message := "\nCommit message\n\nWith a body.\n"
+	commit := headers + message
+
+	sig := testSign(t, signer, commit)
+	gpgsig := "gpgsig " + strings.ReplaceAll(strings.TrimSuffix(sig, "\n"), "\n", "\n ") + "\n"
+	raw := headers + gpgsig + message
+
+	data, extracted := splitCommitSignature(raw)
+	if data != commit {
+		t.Fatalf("Expected signed data %q, got %q", commit, data)
+	}
+	if extracted != sig {
+		t.Fatalf("Expected signature %q, got %q", sig, extracted)
+	}
+
+	if entity, reason := verifySignature(openpgp.EntityList{signer}, data, extracted); entity == nil {
+		t.Errorf("Expected valid signature, got %q", reason)
+	}
+
+	if _, sig := splitCommitSignature(commit); sig != "" {
+		t.Errorf("Expected no signature on unsigned commit, got %q", sig)
+	}
+}
+
+func TestReadArmoredKeyRing(t *testing.T) {
+	for _, s := range []string{"", "not a key"} {
+		if _, err := readArmoredKeyRing(s); err == nil {
+			t.Errorf("Expected error for key ring %q without a key block", s)
+		}
+	}
+}
+
+func TestDataSourceTagVerificationRead(t *testing.T) {
+	signer, err := openpgp.NewEntity("Example", "", "example@example.org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag := "object 0123456789012345678901234567890123456789\n" +
+		"type commit\n" +
+		"tag v1.0.0\n" +
+		"tagger Example <example@example.org> 1761652800 +0000\n" +
+		"\n" +
+		"Release v1.0.0\n"
+	raw := tag + testSign(t, signer, tag)
+
+	config := testConfig(t, func(host, query string, vars map[string]interface{}) interface{} {
+		var repository map[string]interface{}
+		switch {
+		case strings.Contains(query, "query GetRefs"):
+			repository = map[string]interface{}{
+				"references": map[string]interface{}{
+					"results": []interface{}{
+						map[string]interface{}{"name": "refs/tags/v1.0.0", "target": "t1"},
+					},
+					"cursor": nil,
+				},
+			}
+		case strings.Contains(query, "query GetRawObject"):
+			// git.sr.ht returns raw objects base64 encoded
+			repository = map[string]interface{}{
+				"objects": []interface{}{
+					map[string]interface{}{
+						"type":    "TAG",
+						"id":      "t1",
+						"shortId": "t1",
+						"raw":     base64.StdEncoding.EncodeToString([]byte(raw)),
+					},
+				},
+			}
+		default:
+			t.Fatalf("Unexpected query %s", query)
+		}
+		return map[string]interface{}{
+			"user": map[string]interface{}{"repository": repository},
+		}
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceTagVerification().Schema, map[string]interface{}{
+		repoKey:           "repo",
+		tagKey:            "v1.0.0",
+		armoredKeyRingKey: testArmoredPublicKey(t, signer),
+	})
+	if diags := dataSourceTagVerificationRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("Failed to verify tag: %v", diags)
+	}
+
+	if !d.Get(signedKey).(bool) || !d.Get(validKey).(bool) {
+		t.Fatalf("Expected a valid signature, got reason %q", d.Get(reasonKey))
+	}
+	if got, want := d.Get(signerFingerprintKey).(string), fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint); got != want {
+		t.Errorf("Expected signer %s, got %s", want, got)
+	}
+}
@@ -21,6 +21,8 @@ const (
 	locationKey = "location"
 	bioKey      = "bio"
 	pgpKeyKey   = "preferred_pgp_key"
+
+	pgpKeyFingerprintKey = "preferred_pgp_key_fingerprint"
 )
 
 // dataSourceUser returns a data source for getting information about the
@@ -63,14 +65,19 @@ func dataSourceUser() *schema.Resource {
 			pgpKeyKey: {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The users preferred PGP key.",
+				Description: "The users preferred PGP key, empty if none is set.",
+			},
+			pgpKeyFingerprintKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fingerprint of the users preferred PGP key, empty if none is set.",
 			},
 		},
 	}
 }
 
 func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(config)
+	config := meta.(*config)
 	user, err := config.client.GetCurrentUser(context.Background())
 	if err != nil {
 		return err
@@ -102,12 +109,18 @@ func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	// Set preferred PGP key (first one if available)
-	if len(user.PGPKeys.Results) > 0 {
-		err = d.Set(pgpKeyKey, user.PGPKeys.Results[0].Key)
-		if err != nil {
-			return err
-		}
+	// Set preferred PGP key used for encrypted emails (if any)
+	var pgpKey, pgpKeyFingerprint string
+	if user.PGPKey != nil {
+		pgpKey, pgpKeyFingerprint = user.PGPKey.Key, user.PGPKey.Fingerprint
+	}
+	err = d.Set(pgpKeyKey, pgpKey)
+	if err != nil {
+		return err
+	}
+	err = d.Set(pgpKeyFingerprintKey, pgpKeyFingerprint)
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Datasource Name
+	webhookDeliveriesName = "sourcehut_webhook_deliveries"
+
+	// Schema keys
+	serviceKey        = "service"
+	kindKey           = "kind"
+	webhookIDKey      = "webhook_id"
+	limitKey          = "limit"
+	deliveriesKey     = "deliveries"
+	uuidKey           = "uuid"
+	eventKey          = "event"
+	dateKey           = "date"
+	responseStatusKey = "response_status"
+	requestBodyKey    = "request_body"
+	responseBodyKey   = "response_body"
+
+	// maxDeliveryBodyLength is the number of bytes request and response
+	// bodies are truncated to.
+	maxDeliveryBodyLength = 4096
+)
+
+// dataSourceWebhookDeliveries returns a data source for reading the latest
+// deliveries of a webhook of any sourcehut service.
+func dataSourceWebhookDeliveries() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWebhookDeliveriesRead,
+
+		Schema: map[string]*schema.Schema{
+			serviceKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The service the webhook belongs to (eg. 'git.sr.ht').",
+				ValidateFunc: validation.StringInSlice([]string{
+					string(client.GitService),
+					string(client.HgService),
+					string(client.MetaService),
+					string(client.PasteService),
+				}, false),
+			},
+			kindKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "user",
+				Description: `The kind of the webhook, "user" for account-wide webhooks or
+					"repository" for webhooks of a single git repository.`,
+				ValidateFunc: validation.StringInSlice([]string{"user", "repository"}, false),
+			},
+			webhookIDKey: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the webhook.",
+			},
+			limitKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      20,
+				Description:  "The maximum number of deliveries to return.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			deliveriesKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The deliveries, latest first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						uuidKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The UUID of the delivery.",
+						},
+						eventKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The event that triggered the delivery.",
+						},
+						dateKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date of the delivery in RFC3339 format.",
+						},
+						responseStatusKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The HTTP status code of the response, -1 if no response was received.",
+						},
+						requestBodyKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The payload that was sent, truncated to 4096 bytes.",
+						},
+						responseBodyKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The body of the response, truncated to 4096 bytes.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 encoded
+// character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func dataSourceWebhookDeliveriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	service := d.Get(serviceKey).(string)
+	kind := d.Get(kindKey).(string)
+	id := d.Get(webhookIDKey).(int)
+
+	deliveries, err := config.client.GetWebhookDeliveries(ctx,
+		client.Service(service), kind, id, d.Get(limitKey).(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if deliveries == nil {
+		return diag.Errorf("%s webhook %d not found on %s", kind, id, service)
+	}
+
+	list := make([]map[string]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		var responseBody string
+		if delivery.ResponseBody != nil {
+			responseBody = *delivery.ResponseBody
+		}
+
+		list = append(list, map[string]interface{}{
+			uuidKey:           delivery.UUID,
+			eventKey:          delivery.Event,
+			dateKey:           delivery.Date.Format(time.RFC3339),
+			responseStatusKey: delivery.ResponseStatus,
+			requestBodyKey:    truncate(delivery.RequestBody, maxDeliveryBodyLength),
+			responseBodyKey:   truncate(responseBody, maxDeliveryBodyLength),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%d", service, kind, id))
+
+	if err := d.Set(deliveriesKey, list); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting deliveries key: %s", err))
+	}
+
+	return diag.Diagnostics{}
+}
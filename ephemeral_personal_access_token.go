@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Ephemeral Resource Name
+	personalAccessTokenName = "sourcehut_personal_access_token"
+
+	// Schema keys
+	grantsKey    = "grants"
+	expiresInKey = "expires_in"
+
+	// defaultTokenComment is the comment of tokens that don't set one.
+	defaultTokenComment = "Terraform"
+
+	// defaultTokenExpiresIn is the lifetime of tokens that don't set one.
+	defaultTokenExpiresIn = time.Hour
+
+	// privateTokenIDKey is the private data key the token ID is kept under
+	// until the token is revoked.
+	privateTokenIDKey = "token_id"
+)
+
+// grantRegexp matches a single grant, eg. 'git.sr.ht/REPOSITORIES:RW'.
+var grantRegexp = regexp.MustCompile(`^[a-z0-9.-]+/[A-Z_]+(:R[OW])?$`)
+
+// validateGrants checks that grants is a space separated list of grants.
+func validateGrants(grants string) error {
+	fields := strings.Fields(grants)
+	if len(fields) == 0 {
+		return fmt.Errorf("at least one grant is required")
+	}
+	for _, grant := range fields {
+		if !grantRegexp.MatchString(grant) {
+			return fmt.Errorf("invalid grant %q, expected eg. 'git.sr.ht/REPOSITORIES:RO'", grant)
+		}
+	}
+	return nil
+}
+
+// personalAccessTokenEphemeralResource issues a short-lived personal access
+// token that is revoked again once Terraform is done with it. The token is
+// never stored in the state or plan.
+type personalAccessTokenEphemeralResource struct {
+	config *config
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &personalAccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &personalAccessTokenEphemeralResource{}
+
+type personalAccessTokenModel struct {
+	Grants    types.String `tfsdk:"grants"`
+	ExpiresIn types.String `tfsdk:"expires_in"`
+	Comment   types.String `tfsdk:"comment"`
+	Token     types.String `tfsdk:"token"`
+	Expires   types.String `tfsdk:"expires"`
+}
+
+func newPersonalAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &personalAccessTokenEphemeralResource{}
+}
+
+func (r *personalAccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = personalAccessTokenName
+}
+
+func (r *personalAccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `Issues a personal access token on meta.sr.ht, which is revoked
+			again once Terraform is done with it.`,
+		Attributes: map[string]schema.Attribute{
+			grantsKey: schema.StringAttribute{
+				Required: true,
+				Description: `The space separated grants of the token (eg.
+					'git.sr.ht/REPOSITORIES:RO meta.sr.ht/PROFILE:RO').`,
+			},
+			expiresInKey: schema.StringAttribute{
+				Optional: true,
+				Description: `How long the token is valid as a duration (eg. '30m'). The
+					default is 1h.`,
+			},
+			commentKey: schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: `The comment of the token. The default is 'Terraform'. A random
+					suffix is appended on sourcehut, so that tokens issued in parallel can
+					be told apart.`,
+			},
+			tokenKey: schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The personal access token.",
+			},
+			expiresKey: schema.StringAttribute{
+				Computed:    true,
+				Description: "The date on which the token expires in RFC3339 format.",
+			},
+		},
+	}
+}
+
+func (r *personalAccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// The provider isn't configured yet during validation
+	if req.ProviderData == nil {
+		return
+	}
+
+	config, ok := req.ProviderData.(*config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("expected *config, got %T", req.ProviderData))
+		return
+	}
+	r.config = config
+}
+
+func (r *personalAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data personalAccessTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grants := data.Grants.ValueString()
+	if err := validateGrants(grants); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(grantsKey), "Invalid grants", err.Error())
+		return
+	}
+
+	expiresIn := defaultTokenExpiresIn
+	if v := data.ExpiresIn.ValueString(); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root(expiresInKey), "Invalid expiry",
+				fmt.Sprintf("expected a positive duration (eg. '30m'), got %q", v))
+			return
+		}
+		expiresIn = d
+	}
+
+	comment := data.Comment.ValueString()
+	if comment == "" {
+		comment = defaultTokenComment
+	}
+
+	token, details, err := r.config.client.IssuePersonalAccessToken(ctx,
+		strings.Join(strings.Fields(grants), " "), comment, time.Now().Add(expiresIn))
+	if err != nil {
+		resp.Diagnostics.AddError("Error issuing personal access token", err.Error())
+		return
+	}
+
+	// The ID is only kept in the private data, which is handed to Close
+	id, err := json.Marshal(details.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error storing personal access token ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateTokenIDKey, id)...)
+
+	data.Comment = types.StringValue(comment)
+	data.Token = types.StringValue(token)
+	data.Expires = types.StringValue(details.Expires.Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *personalAccessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	b, diags := req.Private.GetKey(ctx, privateTokenIDKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || b == nil {
+		return
+	}
+
+	var id int
+	if err := json.Unmarshal(b, &id); err != nil {
+		resp.Diagnostics.AddError("Error reading personal access token ID", err.Error())
+		return
+	}
+
+	if err := r.config.client.RevokePersonalAccessToken(ctx, id); err != nil {
+		resp.Diagnostics.AddError("Error revoking personal access token", err.Error())
+	}
+}
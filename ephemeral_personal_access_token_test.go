@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import "testing"
+
+func TestValidateGrants(t *testing.T) {
+	tests := []struct {
+		grants  string
+		wantErr bool
+	}{
+		{"git.sr.ht/REPOSITORIES:RO", false},
+		{"git.sr.ht/REPOSITORIES:RW  meta.sr.ht/PROFILE", false},
+		{"", true},
+		{"git.sr.ht/repositories:RO", true},
+		{"git.sr.ht/REPOSITORIES:RX", true},
+		{"REPOSITORIES:RO", true},
+	}
+
+	for _, tt := range tests {
+		err := validateGrants(tt.grants)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateGrants(%q) error = %v, wantErr %v", tt.grants, err, tt.wantErr)
+		}
+	}
+}
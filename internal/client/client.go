@@ -50,6 +50,11 @@ func (c *Client) Git() *gqlclient.Client {
 	return c.getClient(GitService)
 }
 
+// Hg returns a GraphQL client for hg.sr.ht
+func (c *Client) Hg() *gqlclient.Client {
+	return c.getClient(HgService)
+}
+
 // Meta returns a GraphQL client for meta.sr.ht
 func (c *Client) Meta() *gqlclient.Client {
 	return c.getClient(MetaService)
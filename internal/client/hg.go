@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"git.sr.ht/~emersion/gqlclient"
+)
+
+// HgRepository represents a sourcehut mercurial repository
+type HgRepository struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Visibility    string    `json:"visibility"`
+	Readme        string    `json:"readme"`
+	NonPublishing bool      `json:"nonPublishing"`
+	Created       time.Time `json:"created"`
+	Updated       time.Time `json:"updated"`
+	Owner         User      `json:"owner"`
+}
+
+// HgRepositoryInput represents the input parameters for mercurial repository
+// operations
+type HgRepositoryInput struct {
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	Visibility    string `json:"visibility"`
+	Readme        string `json:"readme,omitempty"`
+	NonPublishing bool   `json:"nonPublishing"`
+}
+
+// CreateHgRepository creates a new mercurial repository
+func (c *Client) CreateHgRepository(ctx context.Context, input HgRepositoryInput) (*HgRepository, error) {
+	op := gqlclient.NewOperation(`
+		mutation CreateHgRepo($name: String!, $visibility: Visibility!, $description: String!) {
+			createRepository(
+				name: $name,
+				visibility: $visibility,
+				description: $description
+			) {
+				id
+				name
+				description
+				visibility
+				readme
+				nonPublishing
+				created
+				updated
+				owner {
+					canonicalName
+				}
+			}
+		}
+	`)
+
+	op.Var("name", input.Name)
+	op.Var("visibility", input.Visibility)
+	op.Var("description", input.Description)
+
+	var resp struct {
+		CreateRepository HgRepository `json:"createRepository"`
+	}
+
+	if err := c.Hg().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.CreateRepository, nil
+}
+
+// GetHgRepository retrieves a mercurial repository of the authenticated user
+// by name. It returns nil without error if the repository does not exist.
+func (c *Client) GetHgRepository(ctx context.Context, name string) (*HgRepository, error) {
+	op := gqlclient.NewOperation(`
+		query GetHgRepo($name: String!) {
+			me {
+				repository(name: $name) {
+					id
+					name
+					description
+					visibility
+					readme
+					nonPublishing
+					created
+					updated
+					owner {
+						canonicalName
+					}
+				}
+			}
+		}
+	`)
+
+	op.Var("name", name)
+
+	var resp struct {
+		Me struct {
+			Repository *HgRepository `json:"repository"`
+		} `json:"me"`
+	}
+
+	if err := c.Hg().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Me.Repository, nil
+}
+
+// UpdateHgRepository updates an existing mercurial repository
+func (c *Client) UpdateHgRepository(ctx context.Context, id int, input HgRepositoryInput) (*HgRepository, error) {
+	op := gqlclient.NewOperation(`
+		mutation UpdateHgRepo($id: Int!, $input: RepoInput!) {
+			updateRepository(id: $id, input: $input) {
+				id
+				name
+				description
+				visibility
+				readme
+				nonPublishing
+				created
+				updated
+				owner {
+					canonicalName
+				}
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	inputMap := map[string]interface{}{
+		"description":   input.Description,
+		"visibility":    input.Visibility,
+		"nonPublishing": input.NonPublishing,
+	}
+	if input.Name != "" {
+		inputMap["name"] = input.Name
+	}
+	// An empty readme resets it to the default rendering of the README file
+	if input.Readme != "" {
+		inputMap["readme"] = input.Readme
+	} else {
+		inputMap["readme"] = nil
+	}
+
+	op.Var("input", inputMap)
+
+	var resp struct {
+		UpdateRepository HgRepository `json:"updateRepository"`
+	}
+
+	if err := c.Hg().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.UpdateRepository, nil
+}
+
+// DeleteHgRepository deletes a mercurial repository by ID
+func (c *Client) DeleteHgRepository(ctx context.Context, id int) error {
+	op := gqlclient.NewOperation(`
+		mutation DeleteHgRepo($id: Int!) {
+			deleteRepository(id: $id) {
+				id
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	return c.Hg().Execute(ctx, op, nil)
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~emersion/gqlclient"
+)
+
+// hgTestServer returns a mock hg.sr.ht server that hands every decoded
+// request to handle and encodes the returned data as the response.
+func hgTestServer(t *testing.T, handle func(query string, vars map[string]interface{}) map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{"data": handle(req.Query, req.Variables)}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+}
+
+func hgTestClient(server *httptest.Server) *Client {
+	return &Client{
+		clients: map[Service]*gqlclient.Client{
+			HgService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+}
+
+func hgTestRepo(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            1,
+		"name":          name,
+		"description":   "Test repository",
+		"visibility":    "PUBLIC",
+		"readme":        "<p>Hello</p>",
+		"nonPublishing": true,
+		"created":       "2026-01-02T12:00:00Z",
+		"updated":       "2026-01-02T12:00:00Z",
+		"owner":         map[string]interface{}{"canonicalName": "~example"},
+	}
+}
+
+func TestCreateHgRepository(t *testing.T) {
+	server := hgTestServer(t, func(query string, vars map[string]interface{}) map[string]interface{} {
+		if !strings.Contains(query, "createRepository") {
+			t.Errorf("Expected a createRepository mutation, got %s", query)
+		}
+		if vars["name"] != "test-repo" || vars["visibility"] != "PUBLIC" || vars["description"] != "Test repository" {
+			t.Errorf("Unexpected variables %v", vars)
+		}
+		return map[string]interface{}{"createRepository": hgTestRepo("test-repo")}
+	})
+	defer server.Close()
+
+	repo, err := hgTestClient(server).CreateHgRepository(context.Background(), HgRepositoryInput{
+		Name:        "test-repo",
+		Description: "Test repository",
+		Visibility:  "PUBLIC",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	if repo.ID != 1 || repo.Name != "test-repo" || repo.Owner.CanonicalName != "~example" {
+		t.Errorf("Unexpected repository %+v", repo)
+	}
+	if repo.Readme != "<p>Hello</p>" || !repo.NonPublishing {
+		t.Errorf("Expected readme and non-publishing to be read, got %+v", repo)
+	}
+}
+
+func TestGetHgRepository(t *testing.T) {
+	server := hgTestServer(t, func(query string, vars map[string]interface{}) map[string]interface{} {
+		var repo interface{}
+		if vars["name"] == "test-repo" {
+			repo = hgTestRepo("test-repo")
+		}
+		return map[string]interface{}{
+			"me": map[string]interface{}{"repository": repo},
+		}
+	})
+	defer server.Close()
+
+	c := hgTestClient(server)
+
+	repo, err := c.GetHgRepository(context.Background(), "test-repo")
+	if err != nil {
+		t.Fatalf("Failed to get repository: %v", err)
+	}
+	if repo == nil || repo.ID != 1 || repo.Description != "Test repository" {
+		t.Errorf("Expected repository 1, got %+v", repo)
+	}
+
+	repo, err = c.GetHgRepository(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("Failed to get repository: %v", err)
+	}
+	if repo != nil {
+		t.Errorf("Expected no repository, got %+v", repo)
+	}
+}
+
+func TestUpdateHgRepository(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  HgRepositoryInput
+		expect map[string]interface{}
+	}{
+		{
+			name:  "rename with readme",
+			input: HgRepositoryInput{Name: "renamed", Visibility: "PRIVATE", Readme: "<p>Hello</p>", NonPublishing: true},
+			expect: map[string]interface{}{
+				"name":          "renamed",
+				"description":   "",
+				"visibility":    "PRIVATE",
+				"readme":        "<p>Hello</p>",
+				"nonPublishing": true,
+			},
+		},
+		{
+			name:  "reset readme",
+			input: HgRepositoryInput{Description: "Test repository", Visibility: "PUBLIC"},
+			expect: map[string]interface{}{
+				"description":   "Test repository",
+				"visibility":    "PUBLIC",
+				"readme":        nil,
+				"nonPublishing": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := hgTestServer(t, func(query string, vars map[string]interface{}) map[string]interface{} {
+				if id := vars["id"].(float64); id != 1 {
+					t.Errorf("Expected repository id 1, got %v", id)
+				}
+				input := vars["input"].(map[string]interface{})
+				if len(input) != len(tt.expect) {
+					t.Errorf("Expected input %v, got %v", tt.expect, input)
+				}
+				for k, v := range tt.expect {
+					if got, ok := input[k]; !ok || got != v {
+						t.Errorf("Expected input %s to be %v, got %v", k, v, got)
+					}
+				}
+				return map[string]interface{}{"updateRepository": hgTestRepo("renamed")}
+			})
+			defer server.Close()
+
+			repo, err := hgTestClient(server).UpdateHgRepository(context.Background(), 1, tt.input)
+			if err != nil {
+				t.Fatalf("Failed to update repository: %v", err)
+			}
+			if repo.Name != "renamed" {
+				t.Errorf("Expected repository renamed, got %+v", repo)
+			}
+		})
+	}
+}
+
+func TestDeleteHgRepository(t *testing.T) {
+	var deleted float64
+	server := hgTestServer(t, func(query string, vars map[string]interface{}) map[string]interface{} {
+		if !strings.Contains(query, "deleteRepository") {
+			t.Errorf("Expected a deleteRepository mutation, got %s", query)
+		}
+		deleted = vars["id"].(float64)
+		return map[string]interface{}{"deleteRepository": map[string]interface{}{"id": 1}}
+	})
+	defer server.Close()
+
+	if err := hgTestClient(server).DeleteHgRepository(context.Background(), 1); err != nil {
+		t.Fatalf("Failed to delete repository: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected repository 1 to be deleted, got %v", deleted)
+	}
+}
@@ -6,7 +6,11 @@ package client
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"git.sr.ht/~emersion/gqlclient"
@@ -57,47 +61,69 @@ func (c *Client) CreateSSHKey(ctx context.Context, key string) (*SSHKey, error)
 	return &resp.CreateSSHKey, nil
 }
 
-// GetSSHKey retrieves an SSH key by ID
+// GetSSHKey retrieves an SSH key of the authenticated user by ID. It
+// returns nil without error if there is none.
 func (c *Client) GetSSHKey(ctx context.Context, id int) (*SSHKey, error) {
-	op := gqlclient.NewOperation(`
-		query GetSSHKey($id: Int!) {
-			me {
-				sshKeys {
-					results {
-						id
-						created
-						lastUsed
-						key
-						fingerprint
-						comment
+	keys, err := c.GetSSHKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if key.ID == id {
+			result := key
+			return &result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetSSHKeys retrieves all SSH keys of the authenticated user, following the
+// cursor until every page has been read
+func (c *Client) GetSSHKeys(ctx context.Context) ([]SSHKey, error) {
+	keys := []SSHKey{}
+	var cursor *string
+	for {
+		op := gqlclient.NewOperation(`
+			query GetSSHKeys($cursor: Cursor) {
+				me {
+					sshKeys(cursor: $cursor) {
+						results {
+							id
+							created
+							lastUsed
+							key
+							fingerprint
+							comment
+						}
+						cursor
 					}
 				}
 			}
-		}
-	`)
+		`)
 
-	op.Var("id", id)
+		op.Var("cursor", cursor)
 
-	var resp struct {
-		Me struct {
-			SSHKeys struct {
-				Results []SSHKey `json:"results"`
-			} `json:"sshKeys"`
-		} `json:"me"`
-	}
+		var resp struct {
+			Me struct {
+				SSHKeys struct {
+					Results []SSHKey `json:"results"`
+					Cursor  *string  `json:"cursor"`
+				} `json:"sshKeys"`
+			} `json:"me"`
+		}
 
-	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
-		return nil, err
-	}
+		if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
 
-	// Find the key with matching ID
-	for _, key := range resp.Me.SSHKeys.Results {
-		if key.ID == id {
-			return &key, nil
+		keys = append(keys, resp.Me.SSHKeys.Results...)
+		if resp.Me.SSHKeys.Cursor == nil {
+			return keys, nil
 		}
+		cursor = resp.Me.SSHKeys.Cursor
 	}
-
-	return nil, nil
 }
 
 // DeleteSSHKey deletes an SSH key by ID
@@ -141,46 +167,134 @@ func (c *Client) CreatePGPKey(ctx context.Context, key string) (*PGPKey, error)
 	return &resp.CreatePGPKey, nil
 }
 
-// GetPGPKey retrieves a PGP key by ID
+// GetPGPKey retrieves a PGP key of the authenticated user by ID. It
+// returns nil without error if there is none.
 func (c *Client) GetPGPKey(ctx context.Context, id int) (*PGPKey, error) {
+	keys, err := c.GetPGPKeys(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if key.ID == id {
+			result := key
+			return &result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetPGPKeyByFingerprint retrieves a PGP key of the authenticated user by
+// fingerprint, ignoring case, spaces and colons. It returns nil without
+// error if there is none.
+func (c *Client) GetPGPKeyByFingerprint(ctx context.Context, fingerprint string) (*PGPKey, error) {
 	op := gqlclient.NewOperation(`
-		query GetPGPKey($id: Int!) {
+		query GetPGPKeyByFingerprint($fingerprint: String!) {
 			me {
-				pgpKeys {
-					results {
-						id
-						created
-						key
-						fingerprint
-					}
+				id
+			}
+			pgpKeyByFingerprint(fingerprint: $fingerprint) {
+				id
+				created
+				key
+				fingerprint
+				user {
+					id
 				}
 			}
 		}
 	`)
 
-	op.Var("id", id)
+	op.Var("fingerprint", NormalizeFingerprint(fingerprint))
 
 	var resp struct {
 		Me struct {
-			PGPKeys struct {
-				Results []PGPKey `json:"results"`
-			} `json:"pgpKeys"`
+			ID int `json:"id"`
 		} `json:"me"`
+		PGPKeyByFingerprint *struct {
+			PGPKey
+			User struct {
+				ID int `json:"id"`
+			} `json:"user"`
+		} `json:"pgpKeyByFingerprint"`
 	}
 
 	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
 		return nil, err
 	}
 
-	// Find the key with matching ID
-	for _, key := range resp.Me.PGPKeys.Results {
-		if key.ID == id {
-			result := key
-			return &result, nil
-		}
+	// The lookup isn't limited to the keys of the authenticated user
+	key := resp.PGPKeyByFingerprint
+	if key == nil || key.User.ID != resp.Me.ID {
+		return nil, nil
+	}
+
+	return &key.PGPKey, nil
+}
+
+// NormalizeFingerprint returns a PGP fingerprint in upper case without
+// spaces and colons, the form sourcehut uses.
+func NormalizeFingerprint(fingerprint string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", ":", "").Replace(fingerprint))
+}
+
+// GetPGPKeys retrieves all PGP keys of a user by username, or of the
+// authenticated user if username is empty, following the cursor until every
+// page has been read
+func (c *Client) GetPGPKeys(ctx context.Context, username string) ([]PGPKey, error) {
+	selection, vars := "user: me", ""
+	if username != "" {
+		selection, vars = "user: userByName(username: $username)", ", $username: String!"
 	}
 
-	return nil, fmt.Errorf("PGP key with ID %d not found", id)
+	keys := []PGPKey{}
+	var cursor *string
+	for {
+		op := gqlclient.NewOperation(fmt.Sprintf(`
+			query GetPGPKeys($cursor: Cursor%s) {
+				%s {
+					pgpKeys(cursor: $cursor) {
+						results {
+							id
+							created
+							key
+							fingerprint
+						}
+						cursor
+					}
+				}
+			}
+		`, vars, selection))
+
+		if username != "" {
+			op.Var("username", username)
+		}
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			User *struct {
+				PGPKeys struct {
+					Results []PGPKey `json:"results"`
+					Cursor  *string  `json:"cursor"`
+				} `json:"pgpKeys"`
+			} `json:"user"`
+		}
+
+		if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.User == nil {
+			return nil, fmt.Errorf("user %s not found", username)
+		}
+
+		keys = append(keys, resp.User.PGPKeys.Results...)
+		if resp.User.PGPKeys.Cursor == nil {
+			return keys, nil
+		}
+		cursor = resp.User.PGPKeys.Cursor
+	}
 }
 
 // DeletePGPKey deletes a PGP key by ID
@@ -211,6 +325,11 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 				url
 				location
 				bio
+				pgpKey {
+					id
+					key
+					fingerprint
+				}
 				pgpKeys {
 					results {
 						id
@@ -232,3 +351,453 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 
 	return &resp.Me, nil
 }
+
+// UserInput represents the profile fields to update. Nil fields are left
+// untouched and empty fields are cleared.
+type UserInput struct {
+	URL      *string
+	Location *string
+	Bio      *string
+	Email    *string
+	// PGPKey is the ID of the preferred PGP key, 0 clears it
+	PGPKey *int
+}
+
+// MarshalJSON omits nil fields and sends empty fields as null, which clears
+// them on meta.sr.ht
+func (i UserInput) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{})
+	if i.PGPKey != nil {
+		fields["pgpKey"] = i.PGPKey
+		if *i.PGPKey == 0 {
+			fields["pgpKey"] = nil
+		}
+	}
+	for name, value := range map[string]*string{
+		"url":      i.URL,
+		"location": i.Location,
+		"bio":      i.Bio,
+		"email":    i.Email,
+	} {
+		switch {
+		case value == nil:
+		case *value == "":
+			fields[name] = nil
+		default:
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UpdateUser updates the profile of the authenticated user. A changed email
+// address only takes effect once it has been confirmed.
+func (c *Client) UpdateUser(ctx context.Context, input UserInput) (*User, error) {
+	op := gqlclient.NewOperation(`
+		mutation UpdateUser($input: UserInput!) {
+			updateUser(input: $input) {
+				id
+				username
+				canonicalName
+				created
+				email
+				url
+				location
+				bio
+				pgpKey {
+					id
+					key
+					fingerprint
+				}
+			}
+		}
+	`)
+
+	op.Var("input", input)
+
+	var resp struct {
+		UpdateUser User `json:"updateUser"`
+	}
+
+	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.UpdateUser, nil
+}
+
+// CreateMetaWebhook subscribes a webhook to profile and key events of the
+// authenticated user
+func (c *Client) CreateMetaWebhook(ctx context.Context, input WebhookInput) (*Webhook, error) {
+	op := gqlclient.NewOperation(`
+		mutation CreateWebhook($config: ProfileWebhookInput!) {
+			createWebhook(config: $config) {
+				id
+				events
+				query
+				url
+			}
+		}
+	`)
+
+	op.Var("config", input)
+
+	var resp struct {
+		CreateWebhook Webhook `json:"createWebhook"`
+	}
+
+	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.CreateWebhook, nil
+}
+
+// GetMetaWebhook retrieves a profile webhook of meta.sr.ht by ID. It returns
+// nil without error if the webhook does not exist.
+func (c *Client) GetMetaWebhook(ctx context.Context, id int) (*Webhook, error) {
+	op := gqlclient.NewOperation(`
+		query GetProfileWebhook($id: Int!) {
+			profileWebhook(id: $id) {
+				id
+				events
+				query
+				url
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	var resp struct {
+		ProfileWebhook *Webhook `json:"profileWebhook"`
+	}
+
+	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.ProfileWebhook, nil
+}
+
+// DeleteMetaWebhook deletes a profile webhook of meta.sr.ht by ID
+func (c *Client) DeleteMetaWebhook(ctx context.Context, id int) error {
+	op := gqlclient.NewOperation(`
+		mutation DeleteWebhook($id: Int!) {
+			deleteWebhook(id: $id) {
+				id
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	return c.Meta().Execute(ctx, op, nil)
+}
+
+// OAuthClient represents an OAuth 2.0 client registered on meta.sr.ht
+type OAuthClient struct {
+	ID          int     `json:"id"`
+	UUID        string  `json:"uuid"`
+	RedirectURL string  `json:"redirectUrl"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	URL         *string `json:"url"`
+}
+
+// OAuthClientInput represents the details of an OAuth 2.0 client to register
+type OAuthClientInput struct {
+	RedirectURI string
+	Name        string
+	Description string
+	URL         string
+}
+
+// RegisterOAuthClient registers a new OAuth 2.0 client. The client secret is
+// only returned on registration and can't be retrieved later.
+func (c *Client) RegisterOAuthClient(ctx context.Context, input OAuthClientInput) (*OAuthClient, string, error) {
+	op := gqlclient.NewOperation(`
+		mutation RegisterOAuthClient($redirectUri: String!, $clientName: String!, $clientDescription: String, $clientUrl: String) {
+			registerOAuthClient(redirectUri: $redirectUri, clientName: $clientName, clientDescription: $clientDescription, clientUrl: $clientUrl) {
+				client {
+					id
+					uuid
+					redirectUrl
+					name
+					description
+					url
+				}
+				secret
+			}
+		}
+	`)
+
+	op.Var("redirectUri", input.RedirectURI)
+	op.Var("clientName", input.Name)
+	op.Var("clientDescription", optionalString(input.Description))
+	op.Var("clientUrl", optionalString(input.URL))
+
+	var resp struct {
+		RegisterOAuthClient struct {
+			Client OAuthClient `json:"client"`
+			Secret string      `json:"secret"`
+		} `json:"registerOAuthClient"`
+	}
+
+	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+		return nil, "", err
+	}
+
+	return &resp.RegisterOAuthClient.Client, resp.RegisterOAuthClient.Secret, nil
+}
+
+// GetOAuthClient retrieves an OAuth 2.0 client of the authenticated user by
+// UUID. It returns nil without error if the client does not exist.
+func (c *Client) GetOAuthClient(ctx context.Context, uuid string) (*OAuthClient, error) {
+	op := gqlclient.NewOperation(`
+		query GetOAuthClients {
+			oauthClients {
+				id
+				uuid
+				redirectUrl
+				name
+				description
+				url
+			}
+		}
+	`)
+
+	var resp struct {
+		OAuthClients []OAuthClient `json:"oauthClients"`
+	}
+
+	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, client := range resp.OAuthClients {
+		if client.UUID == uuid {
+			result := client
+			return &result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// RevokeOAuthClient revokes an OAuth 2.0 client by UUID, along with all
+// tokens issued to it
+func (c *Client) RevokeOAuthClient(ctx context.Context, uuid string) error {
+	op := gqlclient.NewOperation(`
+		mutation RevokeOAuthClient($uuid: String!) {
+			revokeOAuthClient(uuid: $uuid) {
+				id
+			}
+		}
+	`)
+
+	op.Var("uuid", uuid)
+
+	return c.Meta().Execute(ctx, op, nil)
+}
+
+// optionalString returns nil for an empty string, so that it is sent as null
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// PersonalAccessToken represents a personal access token of the
+// authenticated user. The token itself is only returned when it is issued.
+type PersonalAccessToken struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+	Expires time.Time `json:"expires"`
+	Comment *string   `json:"comment"`
+	Grants  *string   `json:"grants"`
+}
+
+// IssuePersonalAccessToken issues a personal access token with the given
+// grants that expires at the given time. A random nonce is appended to the
+// comment, so the token can be told apart from tokens issued in parallel. It
+// returns the token along with its details, which are needed to revoke it
+// again.
+func (c *Client) IssuePersonalAccessToken(ctx context.Context, grants, comment string, expires time.Time) (string, *PersonalAccessToken, error) {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	comment = strings.TrimSpace(fmt.Sprintf("%s %x", comment, nonce))
+
+	op := gqlclient.NewOperation(`
+		mutation IssuePersonalAccessToken($grants: String, $comment: String, $expires: Time) {
+			issuePersonalAccessToken(grants: $grants, comment: $comment, expires: $expires) {
+				token
+				expires
+			}
+		}
+	`)
+
+	op.Var("grants", optionalString(grants))
+	op.Var("comment", comment)
+	op.Var("expires", expires.UTC())
+
+	var resp struct {
+		IssuePersonalAccessToken struct {
+			Token   string    `json:"token"`
+			Expires time.Time `json:"expires"`
+		} `json:"issuePersonalAccessToken"`
+	}
+
+	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+		return "", nil, err
+	}
+
+	// The token ID isn't part of the response, it is looked up by the
+	// unique comment among the tokens of the user
+	token, err := c.findPersonalAccessToken(ctx, comment)
+	if err != nil {
+		// Without its ID the token couldn't be revoked later on, so it
+		// doesn't outlive the failed attempt
+		if revokeErr := c.revokePersonalAccessTokenByComment(ctx, comment); revokeErr != nil {
+			return "", nil, errors.Join(err, revokeErr)
+		}
+		return "", nil, err
+	}
+
+	return resp.IssuePersonalAccessToken.Token, token, nil
+}
+
+// findPersonalAccessToken looks up a personal access token of the
+// authenticated user by its comment
+func (c *Client) findPersonalAccessToken(ctx context.Context, comment string) (*PersonalAccessToken, error) {
+	tokens, err := c.GetPersonalAccessTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.Comment != nil && *token.Comment == comment {
+			result := token
+			return &result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("personal access token %q not found", comment)
+}
+
+// revokePersonalAccessTokenByComment looks up a personal access token by its
+// comment once more and revokes it
+func (c *Client) revokePersonalAccessTokenByComment(ctx context.Context, comment string) error {
+	token, err := c.findPersonalAccessToken(ctx, comment)
+	if err != nil {
+		return fmt.Errorf("error revoking personal access token %q, it has to be revoked manually: %w", comment, err)
+	}
+	return c.RevokePersonalAccessToken(ctx, token.ID)
+}
+
+// GetPersonalAccessTokens retrieves the personal access tokens of the
+// authenticated user
+func (c *Client) GetPersonalAccessTokens(ctx context.Context) ([]PersonalAccessToken, error) {
+	op := gqlclient.NewOperation(`
+		query GetPersonalAccessTokens {
+			personalAccessTokens {
+				id
+				created
+				expires
+				comment
+				grants
+			}
+		}
+	`)
+
+	var resp struct {
+		PersonalAccessTokens []PersonalAccessToken `json:"personalAccessTokens"`
+	}
+
+	if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.PersonalAccessTokens, nil
+}
+
+// RevokePersonalAccessToken revokes a personal access token by ID
+func (c *Client) RevokePersonalAccessToken(ctx context.Context, id int) error {
+	op := gqlclient.NewOperation(`
+		mutation RevokePersonalAccessToken($id: Int!) {
+			revokePersonalAccessToken(id: $id) {
+				id
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	return c.Meta().Execute(ctx, op, nil)
+}
+
+// AuditLogEntry represents an entry of the audit log of the authenticated
+// user
+type AuditLogEntry struct {
+	ID        int       `json:"id"`
+	Created   time.Time `json:"created"`
+	IPAddress string    `json:"ipAddress"`
+	EventType string    `json:"eventType"`
+	Details   *string   `json:"details"`
+}
+
+// GetAuditLog retrieves the audit log of the authenticated user, latest
+// entries first. Pages are followed until every entry since the given time
+// has been read, a zero time reads the whole log.
+func (c *Client) GetAuditLog(ctx context.Context, since time.Time) ([]AuditLogEntry, error) {
+	entries := []AuditLogEntry{}
+	var cursor *string
+	for {
+		op := gqlclient.NewOperation(`
+			query GetAuditLog($cursor: Cursor) {
+				me {
+					auditLog(cursor: $cursor) {
+						results {
+							id
+							created
+							ipAddress
+							eventType
+							details
+						}
+						cursor
+					}
+				}
+			}
+		`)
+
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			Me struct {
+				AuditLog struct {
+					Results []AuditLogEntry `json:"results"`
+					Cursor  *string         `json:"cursor"`
+				} `json:"auditLog"`
+			} `json:"me"`
+		}
+
+		if err := c.Meta().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range resp.Me.AuditLog.Results {
+			if entry.Created.Before(since) {
+				// Older entries are on the following pages only
+				return entries, nil
+			}
+			entries = append(entries, entry)
+		}
+		if resp.Me.AuditLog.Cursor == nil {
+			return entries, nil
+		}
+		cursor = resp.Me.AuditLog.Cursor
+	}
+}
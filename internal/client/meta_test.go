@@ -0,0 +1,461 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~emersion/gqlclient"
+)
+
+func TestGetPGPKey(t *testing.T) {
+	// Mock server returning two pages of PGP keys
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		var keys map[string]interface{}
+		switch req.Variables["cursor"] {
+		case nil:
+			keys = map[string]interface{}{
+				"results": []interface{}{
+					map[string]interface{}{
+						"id":          1,
+						"created":     "2026-01-02T12:00:00Z",
+						"key":         "first",
+						"fingerprint": "0123456789ABCDEF0123456789ABCDEF01234567",
+					},
+				},
+				"cursor": "next",
+			}
+		case "next":
+			keys = map[string]interface{}{
+				"results": []interface{}{
+					map[string]interface{}{
+						"id":          2,
+						"created":     "2026-01-02T12:00:00Z",
+						"key":         "second",
+						"fingerprint": "89ABCDEF0123456789ABCDEF0123456789ABCDEF",
+					},
+				},
+				"cursor": nil,
+			}
+		default:
+			t.Fatalf("Unexpected cursor %v", req.Variables["cursor"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"user": map[string]interface{}{"pgpKeys": keys},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			MetaService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	key, err := c.GetPGPKey(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Failed to get PGP key: %v", err)
+	}
+	if key == nil || key.Key != "second" {
+		t.Errorf("Expected key 2, got %+v", key)
+	}
+
+	key, err = c.GetPGPKey(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Failed to get PGP key: %v", err)
+	}
+	if key != nil {
+		t.Errorf("Expected no key, got %+v", key)
+	}
+}
+
+func TestGetPGPKeyByFingerprint(t *testing.T) {
+	// Mock server knowing one key of the authenticated user and one of
+	// another user
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(req.Query, "pgpKeys") {
+			t.Error("Expected a single lookup instead of listing every key")
+		}
+
+		var key interface{}
+		switch fingerprint := req.Variables["fingerprint"]; fingerprint {
+		case "89ABCDEF0123456789ABCDEF0123456789ABCDEF":
+			key = map[string]interface{}{
+				"id":          2,
+				"created":     "2026-01-02T12:00:00Z",
+				"key":         "second",
+				"fingerprint": fingerprint,
+				"user":        map[string]interface{}{"id": 1},
+			}
+		case "0123456789ABCDEF0123456789ABCDEF01234567":
+			key = map[string]interface{}{
+				"id":          3,
+				"created":     "2026-01-02T12:00:00Z",
+				"key":         "foreign",
+				"fingerprint": fingerprint,
+				"user":        map[string]interface{}{"id": 2},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"me":                  map[string]interface{}{"id": 1},
+				"pgpKeyByFingerprint": key,
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			MetaService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	key, err := c.GetPGPKeyByFingerprint(context.Background(),
+		"89ab cdef 0123 4567 89ab:cdef:0123:4567:89ab:cdef")
+	if err != nil {
+		t.Fatalf("Failed to get PGP key: %v", err)
+	}
+	if key == nil || key.ID != 2 || key.Key != "second" {
+		t.Errorf("Expected key 2, got %+v", key)
+	}
+
+	// Keys of other users aren't returned
+	key, err = c.GetPGPKeyByFingerprint(context.Background(), "0123456789abcdef0123456789abcdef01234567")
+	if err != nil {
+		t.Fatalf("Failed to get PGP key: %v", err)
+	}
+	if key != nil {
+		t.Errorf("Expected no key of another user, got %+v", key)
+	}
+
+	key, err = c.GetPGPKeyByFingerprint(context.Background(), "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF")
+	if err != nil {
+		t.Fatalf("Failed to get PGP key: %v", err)
+	}
+	if key != nil {
+		t.Errorf("Expected no key, got %+v", key)
+	}
+}
+
+func TestUserInputMarshalJSON(t *testing.T) {
+	url := "https://example.org"
+	empty := ""
+	keyID, noKey := 42, 0
+
+	tests := []struct {
+		name  string
+		input UserInput
+		want  string
+	}{
+		{"unset fields are omitted", UserInput{}, `{}`},
+		{"empty fields are cleared", UserInput{Bio: &empty}, `{"bio":null}`},
+		{"values are sent", UserInput{URL: &url, Location: &empty}, `{"location":null,"url":"https://example.org"}`},
+		{"preferred PGP key is sent by ID", UserInput{PGPKey: &keyID}, `{"pgpKey":42}`},
+		{"preferred PGP key is cleared", UserInput{PGPKey: &noKey}, `{"pgpKey":null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterOAuthClient(t *testing.T) {
+	// Mock server echoing the client details along with a secret
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if v, ok := req.Variables["clientDescription"]; !ok || v != nil {
+			t.Errorf("Expected empty description to be sent as null, got %v", v)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"registerOAuthClient": map[string]interface{}{
+					"client": map[string]interface{}{
+						"id":          3,
+						"uuid":        "8c7e5e4a-5d1f-4b8e-9f6a-0e0f2c7d9b1a",
+						"redirectUrl": req.Variables["redirectUri"],
+						"name":        req.Variables["clientName"],
+						"description": req.Variables["clientDescription"],
+						"url":         req.Variables["clientUrl"],
+					},
+					"secret": "s3cret",
+				},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			MetaService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	client, secret, err := c.RegisterOAuthClient(context.Background(), OAuthClientInput{
+		RedirectURI: "https://example.org/callback",
+		Name:        "example",
+		URL:         "https://example.org",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register OAuth client: %v", err)
+	}
+
+	if secret != "s3cret" {
+		t.Errorf("Expected secret %q, got %q", "s3cret", secret)
+	}
+	if client.Description != nil {
+		t.Errorf("Expected no description, got %q", *client.Description)
+	}
+	if client.URL == nil || *client.URL != "https://example.org" {
+		t.Errorf("Expected URL %q, got %v", "https://example.org", client.URL)
+	}
+}
+
+func TestGetAuditLog(t *testing.T) {
+	// Mock server returning two pages of audit log entries, latest first
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		var log map[string]interface{}
+		switch req.Variables["cursor"] {
+		case nil:
+			log = map[string]interface{}{
+				"results": []interface{}{
+					map[string]interface{}{
+						"id":        3,
+						"created":   "2026-03-01T12:00:00Z",
+						"ipAddress": "192.0.2.1",
+						"eventType": "ssh_key_added",
+						"details":   "SSH key added",
+					},
+				},
+				"cursor": "next",
+			}
+		case "next":
+			log = map[string]interface{}{
+				"results": []interface{}{
+					map[string]interface{}{
+						"id":        2,
+						"created":   "2026-02-01T12:00:00Z",
+						"ipAddress": "192.0.2.1",
+						"eventType": "logged_in",
+						"details":   nil,
+					},
+					map[string]interface{}{
+						"id":        1,
+						"created":   "2026-01-01T12:00:00Z",
+						"ipAddress": "192.0.2.2",
+						"eventType": "logged_in",
+						"details":   nil,
+					},
+				},
+				"cursor": "last",
+			}
+		default:
+			t.Fatalf("Unexpected cursor %v", req.Variables["cursor"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"me": map[string]interface{}{"auditLog": log},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			MetaService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	since := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	entries, err := c.GetAuditLog(context.Background(), since)
+	if err != nil {
+		t.Fatalf("Failed to get audit log: %v", err)
+	}
+
+	if len(entries) != 2 || entries[0].ID != 3 || entries[1].ID != 2 {
+		t.Errorf("Expected entries 3 and 2, got %+v", entries)
+	}
+	if entries[1].Details != nil {
+		t.Errorf("Expected no details, got %q", *entries[1].Details)
+	}
+	// Entries older than since end the pagination early
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+// personalAccessTokenServer mocks the personal access token API. The issued
+// token gets ID 2, while another token with the same default comment and
+// expiry already exists. The first failLookups lookups of the tokens fail.
+func personalAccessTokenServer(t *testing.T, failLookups int, revoked *[]int) *httptest.Server {
+	var comment string
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		expires := "2026-01-02T13:00:00Z"
+		var data map[string]interface{}
+		switch {
+		case strings.Contains(req.Query, "issuePersonalAccessToken"):
+			comment = req.Variables["comment"].(string)
+			data = map[string]interface{}{
+				"issuePersonalAccessToken": map[string]interface{}{
+					"token":   "secret",
+					"expires": expires,
+				},
+			}
+		case strings.Contains(req.Query, "personalAccessTokens"):
+			if failLookups > 0 {
+				failLookups--
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			data = map[string]interface{}{
+				"personalAccessTokens": []interface{}{
+					map[string]interface{}{"id": 1, "comment": "Terraform", "expires": expires},
+					map[string]interface{}{"id": 2, "comment": comment, "expires": expires},
+					map[string]interface{}{"id": 3, "comment": "Terraform", "expires": expires},
+				},
+			}
+		case strings.Contains(req.Query, "revokePersonalAccessToken"):
+			*revoked = append(*revoked, int(req.Variables["id"].(float64)))
+			data = map[string]interface{}{
+				"revokePersonalAccessToken": map[string]interface{}{"id": req.Variables["id"]},
+			}
+		default:
+			t.Fatalf("Unexpected query %s", req.Query)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"data": data}); err != nil {
+			t.Fatal(err)
+		}
+	}))
+}
+
+func TestIssuePersonalAccessToken(t *testing.T) {
+	expires := time.Date(2026, 1, 2, 13, 0, 0, 0, time.UTC)
+
+	t.Run("parallel tokens", func(t *testing.T) {
+		var revoked []int
+		server := personalAccessTokenServer(t, 0, &revoked)
+		defer server.Close()
+
+		c := &Client{
+			clients: map[Service]*gqlclient.Client{
+				MetaService: gqlclient.New(server.URL, http.DefaultClient),
+			},
+		}
+
+		token, details, err := c.IssuePersonalAccessToken(context.Background(),
+			"meta.sr.ht/PROFILE:RO", "Terraform", expires)
+		if err != nil {
+			t.Fatalf("Failed to issue token: %v", err)
+		}
+		if token != "secret" {
+			t.Errorf("Expected token %q, got %q", "secret", token)
+		}
+		if details.ID != 2 {
+			t.Errorf("Expected token ID 2, got %d", details.ID)
+		}
+		if len(revoked) != 0 {
+			t.Errorf("Expected no token to be revoked, got %v", revoked)
+		}
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		var revoked []int
+		server := personalAccessTokenServer(t, 1, &revoked)
+		defer server.Close()
+
+		c := &Client{
+			clients: map[Service]*gqlclient.Client{
+				MetaService: gqlclient.New(server.URL, http.DefaultClient),
+			},
+		}
+
+		if _, _, err := c.IssuePersonalAccessToken(context.Background(),
+			"meta.sr.ht/PROFILE:RO", "Terraform", expires); err == nil {
+			t.Fatal("Expected an error when the token ID can't be looked up")
+		}
+		if len(revoked) != 1 || revoked[0] != 2 {
+			t.Errorf("Expected the issued token 2 to be revoked, got %v", revoked)
+		}
+	})
+}
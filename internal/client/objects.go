@@ -0,0 +1,538 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"git.sr.ht/~emersion/gqlclient"
+)
+
+// Object represents a git object. Text is only set for text blobs and
+// Base64 only for binary blobs.
+type Object struct {
+	Typename string `json:"__typename"`
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	ShortID  string `json:"shortId"`
+	Raw      string `json:"raw"`
+	Text     string `json:"text"`
+	Base64   string `json:"base64"`
+}
+
+// IsBinary reports whether the object is a binary blob
+func (o *Object) IsBinary() bool {
+	return o.Typename == "BinaryBlob"
+}
+
+// Size returns the size of a blob in bytes. git.sr.ht doesn't expose it, so
+// it is derived from the contents.
+func (o *Object) Size() int {
+	if o.IsBinary() {
+		b, _ := base64.StdEncoding.DecodeString(o.Base64)
+		return len(b)
+	}
+	return len(o.Text)
+}
+
+// TreeEntry represents an entry of a git tree
+type TreeEntry struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Mode   int    `json:"mode"`
+	Object Object `json:"object"`
+}
+
+// GetRepositoryPath retrieves the tree entry at path in the revision revspec
+// of a repository owned by username, or by the authenticated user if
+// username is empty. Blob contents are included. It also returns the ID of
+// the commit revspec resolved to. A nil entry without error is returned if
+// the path does not exist.
+func (c *Client) GetRepositoryPath(ctx context.Context, username, repo, revspec, path string) (*TreeEntry, string, error) {
+	selection, vars := userQuery(username)
+
+	op := gqlclient.NewOperation(fmt.Sprintf(`
+		query GetRepoPath($repo: String!, $revspec: String!, $path: String!%s) {
+			%s {
+				repository(name: $repo) {
+					commit: revparse_single(revspec: $revspec) {
+						id
+					}
+					path(revspec: $revspec, path: $path) {
+						id
+						name
+						mode
+						object {
+							__typename
+							type
+							id
+							shortId
+							... on TextBlob {
+								text
+							}
+							... on BinaryBlob {
+								base64
+							}
+						}
+					}
+				}
+			}
+		}
+	`, vars, selection))
+
+	if username != "" {
+		op.Var("username", username)
+	}
+	op.Var("repo", repo)
+	op.Var("revspec", revspec)
+	op.Var("path", path)
+
+	var resp struct {
+		User *struct {
+			Repository *struct {
+				Commit *struct {
+					ID string `json:"id"`
+				} `json:"commit"`
+				Path *TreeEntry `json:"path"`
+			} `json:"repository"`
+		} `json:"user"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, "", err
+	}
+
+	if resp.User == nil || resp.User.Repository == nil {
+		return nil, "", fmt.Errorf("repository %s not found", repo)
+	}
+
+	if resp.User.Repository.Commit == nil {
+		return nil, "", fmt.Errorf("revision %s not found in repository %s", revspec, repo)
+	}
+
+	return resp.User.Repository.Path, resp.User.Repository.Commit.ID, nil
+}
+
+// Reference represents a git reference, eg. a branch or tag. Commit is the
+// ID of the commit the reference points to, with annotated tags peeled.
+type Reference struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+	Commit string `json:"-"`
+}
+
+// GetReferences retrieves all references of a repository owned by username,
+// or by the authenticated user if username is empty, following the cursor
+// until every page has been read
+func (c *Client) GetReferences(ctx context.Context, username, repo string) ([]Reference, error) {
+	selection, vars := userQuery(username)
+
+	refs := []Reference{}
+	var cursor *string
+	for {
+		op := gqlclient.NewOperation(fmt.Sprintf(`
+			query GetRefs($repo: String!, $cursor: Cursor%s) {
+				%s {
+					repository(name: $repo) {
+						references(cursor: $cursor) {
+							results {
+								name
+								target
+								follow {
+									type
+									id
+									... on Tag {
+										target {
+											id
+										}
+									}
+								}
+							}
+							cursor
+						}
+					}
+				}
+			}
+		`, vars, selection))
+
+		if username != "" {
+			op.Var("username", username)
+		}
+		op.Var("repo", repo)
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			User *struct {
+				Repository *struct {
+					References struct {
+						Results []struct {
+							Reference
+							Follow *struct {
+								Type   string `json:"type"`
+								ID     string `json:"id"`
+								Target *struct {
+									ID string `json:"id"`
+								} `json:"target"`
+							} `json:"follow"`
+						} `json:"results"`
+						Cursor *string `json:"cursor"`
+					} `json:"references"`
+				} `json:"repository"`
+			} `json:"user"`
+		}
+
+		if err := c.Git().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.User == nil || resp.User.Repository == nil {
+			return nil, fmt.Errorf("repository %s not found", repo)
+		}
+
+		for _, r := range resp.User.Repository.References.Results {
+			ref := r.Reference
+			ref.Commit = ref.Target
+			if r.Follow != nil && r.Follow.Type == "TAG" && r.Follow.Target != nil {
+				ref.Commit = r.Follow.Target.ID
+			}
+			refs = append(refs, ref)
+		}
+
+		if resp.User.Repository.References.Cursor == nil {
+			return refs, nil
+		}
+		cursor = resp.User.Repository.References.Cursor
+	}
+}
+
+// Signature represents the author or committer of a git commit
+type Signature struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Time  time.Time `json:"time"`
+}
+
+// Commit represents a git commit. Only the ID is set for its parents.
+type Commit struct {
+	ID        string    `json:"id"`
+	ShortID   string    `json:"shortId"`
+	Author    Signature `json:"author"`
+	Committer Signature `json:"committer"`
+	Message   string    `json:"message"`
+	Parents   []Commit  `json:"parents"`
+}
+
+// GetLog retrieves up to limit commits of a repository owned by username, or
+// by the authenticated user if username is empty, walking the history from
+// the revision from (HEAD if empty) and following the cursor as needed
+func (c *Client) GetLog(ctx context.Context, username, repo, from string, limit int) ([]Commit, error) {
+	selection, vars := userQuery(username)
+
+	commits := []Commit{}
+	var cursor *string
+	for len(commits) < limit {
+		op := gqlclient.NewOperation(fmt.Sprintf(`
+			query GetLog($repo: String!, $from: String, $cursor: Cursor%s) {
+				%s {
+					repository(name: $repo) {
+						log(from: $from, cursor: $cursor) {
+							results {
+								id
+								shortId
+								author {
+									name
+									email
+									time
+								}
+								committer {
+									name
+									email
+									time
+								}
+								message
+								parents {
+									id
+								}
+							}
+							cursor
+						}
+					}
+				}
+			}
+		`, vars, selection))
+
+		if username != "" {
+			op.Var("username", username)
+		}
+		op.Var("repo", repo)
+		// Without the variable the argument is omitted, which makes the
+		// server start at HEAD
+		if from != "" {
+			op.Var("from", from)
+		}
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			User *struct {
+				Repository *struct {
+					Log struct {
+						Results []Commit `json:"results"`
+						Cursor  *string  `json:"cursor"`
+					} `json:"log"`
+				} `json:"repository"`
+			} `json:"user"`
+		}
+
+		if err := c.Git().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.User == nil || resp.User.Repository == nil {
+			return nil, fmt.Errorf("repository %s not found", repo)
+		}
+
+		commits = append(commits, resp.User.Repository.Log.Results...)
+		if resp.User.Repository.Log.Cursor == nil {
+			break
+		}
+		cursor = resp.User.Repository.Log.Cursor
+	}
+
+	if len(commits) > limit {
+		commits = commits[:limit]
+	}
+
+	return commits, nil
+}
+
+// GetTree retrieves the entries of the tree at path in the revision revspec
+// of a repository owned by username, or by the authenticated user if
+// username is empty. The root tree is read if path is empty. Subtrees are
+// not descended into and only the ID, name and mode of each entry are set.
+func (c *Client) GetTree(ctx context.Context, username, repo, revspec, path string) ([]TreeEntry, error) {
+	selection, vars := userQuery(username)
+
+	entries := []TreeEntry{}
+	var cursor *string
+	for {
+		var op *gqlclient.Operation
+		if path == "" {
+			op = gqlclient.NewOperation(fmt.Sprintf(`
+				query GetTree($repo: String!, $revspec: String!, $cursor: Cursor%s) {
+					%s {
+						repository(name: $repo) {
+							object: revparse_single(revspec: $revspec) {
+								tree {
+									entries(cursor: $cursor) {
+										results {
+											id
+											name
+											mode
+										}
+										cursor
+									}
+								}
+							}
+						}
+					}
+				}
+			`, vars, selection))
+		} else {
+			op = gqlclient.NewOperation(fmt.Sprintf(`
+				query GetTree($repo: String!, $revspec: String!, $path: String!, $cursor: Cursor%s) {
+					%s {
+						repository(name: $repo) {
+							path(revspec: $revspec, path: $path) {
+								object {
+									type
+									... on Tree {
+										entries(cursor: $cursor) {
+											results {
+												id
+												name
+												mode
+											}
+											cursor
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			`, vars, selection))
+			op.Var("path", path)
+		}
+
+		if username != "" {
+			op.Var("username", username)
+		}
+		op.Var("repo", repo)
+		op.Var("revspec", revspec)
+		op.Var("cursor", cursor)
+
+		type treeEntries struct {
+			Entries struct {
+				Results []TreeEntry `json:"results"`
+				Cursor  *string     `json:"cursor"`
+			} `json:"entries"`
+		}
+
+		var resp struct {
+			User *struct {
+				Repository *struct {
+					Object *struct {
+						Tree treeEntries `json:"tree"`
+					} `json:"object"`
+					Path *struct {
+						Object struct {
+							Type string `json:"type"`
+							treeEntries
+						} `json:"object"`
+					} `json:"path"`
+				} `json:"repository"`
+			} `json:"user"`
+		}
+
+		if err := c.Git().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.User == nil || resp.User.Repository == nil {
+			return nil, fmt.Errorf("repository %s not found", repo)
+		}
+
+		var tree treeEntries
+		switch r := resp.User.Repository; {
+		case path == "" && r.Object == nil:
+			return nil, fmt.Errorf("revision %s not found in repository %s", revspec, repo)
+		case path == "":
+			tree = r.Object.Tree
+		case r.Path == nil:
+			return nil, fmt.Errorf("path %s not found in repository %s", path, repo)
+		case r.Path.Object.Type != "TREE":
+			return nil, fmt.Errorf("%s in repository %s is not a directory", path, repo)
+		default:
+			tree = r.Path.Object.treeEntries
+		}
+
+		entries = append(entries, tree.Entries.Results...)
+		if tree.Entries.Cursor == nil {
+			return entries, nil
+		}
+		cursor = tree.Entries.Cursor
+	}
+}
+
+// RevParse resolves a revision (eg. a branch, tag or abbreviated ID) of a
+// repository owned by username, or by the authenticated user if username is
+// empty, to the ID of a commit
+func (c *Client) RevParse(ctx context.Context, username, repo, revspec string) (string, error) {
+	selection, vars := userQuery(username)
+
+	op := gqlclient.NewOperation(fmt.Sprintf(`
+		query RevParse($repo: String!, $revspec: String!%s) {
+			%s {
+				repository(name: $repo) {
+					commit: revparse_single(revspec: $revspec) {
+						id
+					}
+				}
+			}
+		}
+	`, vars, selection))
+
+	if username != "" {
+		op.Var("username", username)
+	}
+	op.Var("repo", repo)
+	op.Var("revspec", revspec)
+
+	var resp struct {
+		User *struct {
+			Repository *struct {
+				Commit *struct {
+					ID string `json:"id"`
+				} `json:"commit"`
+			} `json:"repository"`
+		} `json:"user"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return "", err
+	}
+
+	if resp.User == nil || resp.User.Repository == nil {
+		return "", fmt.Errorf("repository %s not found", repo)
+	}
+
+	if resp.User.Repository.Commit == nil {
+		return "", fmt.Errorf("revision %s not found in repository %s", revspec, repo)
+	}
+
+	return resp.User.Repository.Commit.ID, nil
+}
+
+// GetRawObject retrieves a git object of a repository owned by username, or
+// by the authenticated user if username is empty, by its ID including its
+// raw contents. git.sr.ht returns them base64 encoded, Raw is set to the
+// decoded contents. It returns nil without error if the object does not
+// exist.
+func (c *Client) GetRawObject(ctx context.Context, username, repo, id string) (*Object, error) {
+	selection, vars := userQuery(username)
+
+	op := gqlclient.NewOperation(fmt.Sprintf(`
+		query GetRawObject($repo: String!, $ids: [String!]%s) {
+			%s {
+				repository(name: $repo) {
+					objects(ids: $ids) {
+						type
+						id
+						shortId
+						raw
+					}
+				}
+			}
+		}
+	`, vars, selection))
+
+	if username != "" {
+		op.Var("username", username)
+	}
+	op.Var("repo", repo)
+	op.Var("ids", []string{id})
+
+	var resp struct {
+		User *struct {
+			Repository *struct {
+				Objects []*Object `json:"objects"`
+			} `json:"repository"`
+		} `json:"user"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.User == nil || resp.User.Repository == nil {
+		return nil, fmt.Errorf("repository %s not found", repo)
+	}
+
+	if len(resp.User.Repository.Objects) == 0 || resp.User.Repository.Objects[0] == nil {
+		return nil, nil
+	}
+
+	object := resp.User.Repository.Objects[0]
+	raw, err := base64.StdEncoding.DecodeString(object.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding raw object %s: %w", object.ID, err)
+	}
+	object.Raw = string(raw)
+
+	return object, nil
+}
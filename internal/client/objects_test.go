@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.sr.ht/~emersion/gqlclient"
+)
+
+func TestGetLog(t *testing.T) {
+	pages := 0
+
+	// Mock server returning an endless log, two commits per page
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if req.Variables["username"] != "example" {
+			t.Errorf("Expected username example, got %v", req.Variables["username"])
+		}
+		if from, ok := req.Variables["from"]; ok {
+			t.Errorf("Expected no from revision, got %v", from)
+		}
+
+		var results []interface{}
+		for i := 0; i < 2; i++ {
+			results = append(results, map[string]interface{}{
+				"id":        fmt.Sprintf("commit-%d-%d", pages, i),
+				"shortId":   "commit",
+				"author":    map[string]interface{}{"name": "Alice", "email": "alice@example.org", "time": "2025-10-28T12:00:00Z"},
+				"committer": map[string]interface{}{"name": "Bob", "email": "bob@example.org", "time": "2025-10-28T12:00:00Z"},
+				"message":   "Commit message\n",
+				"parents":   []interface{}{map[string]interface{}{"id": "parent"}},
+			})
+		}
+		pages++
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"user": map[string]interface{}{
+					"repository": map[string]interface{}{
+						"log": map[string]interface{}{
+							"results": results,
+							"cursor":  fmt.Sprintf("page-%d", pages),
+						},
+					},
+				},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			GitService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	commits, err := c.GetLog(context.Background(), "example", "repo", "", 3)
+	if err != nil {
+		t.Fatalf("Failed to get log: %v", err)
+	}
+
+	if len(commits) != 3 {
+		t.Fatalf("Expected 3 commits, got %d", len(commits))
+	}
+	if pages != 2 {
+		t.Errorf("Expected 2 pages to be requested, got %d", pages)
+	}
+	if commits[2].ID != "commit-1-0" {
+		t.Errorf("Expected last commit commit-1-0, got %s", commits[2].ID)
+	}
+	if commits[0].Author.Email != "alice@example.org" || commits[0].Parents[0].ID != "parent" {
+		t.Errorf("Unexpected commit %+v", commits[0])
+	}
+}
+
+func TestGetTree(t *testing.T) {
+	// Mock server returning a subdirectory with one file
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if req.Variables["path"] != ".builds" {
+			t.Errorf("Expected path .builds, got %v", req.Variables["path"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"user": map[string]interface{}{
+					"repository": map[string]interface{}{
+						"path": map[string]interface{}{
+							"object": map[string]interface{}{
+								"type": "TREE",
+								"entries": map[string]interface{}{
+									"results": []interface{}{
+										map[string]interface{}{"id": "abc", "name": "test.yml", "mode": 0o100644},
+									},
+									"cursor": nil,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			GitService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	entries, err := c.GetTree(context.Background(), "", "repo", "HEAD", ".builds")
+	if err != nil {
+		t.Fatalf("Failed to get tree: %v", err)
+	}
+
+	if len(entries) != 1 || entries[0].Name != "test.yml" || entries[0].Mode != 0o100644 {
+		t.Errorf("Unexpected entries %+v", entries)
+	}
+}
+
+func TestGetRawObject(t *testing.T) {
+	raw := "object 0123456789012345678901234567890123456789\ntype commit\ntag v1.0.0\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		// git.sr.ht returns raw objects base64 encoded
+		objects := []interface{}{}
+		if ids := req.Variables["ids"].([]interface{}); ids[0] == "t1" {
+			objects = append(objects, map[string]interface{}{
+				"type":    "TAG",
+				"id":      "t1",
+				"shortId": "t1",
+				"raw":     base64.StdEncoding.EncodeToString([]byte(raw)),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"user": map[string]interface{}{
+					"repository": map[string]interface{}{"objects": objects},
+				},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			GitService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	object, err := c.GetRawObject(context.Background(), "", "repo", "t1")
+	if err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+	if object == nil || object.Raw != raw {
+		t.Errorf("Expected decoded raw object %q, got %+v", raw, object)
+	}
+
+	object, err = c.GetRawObject(context.Background(), "", "repo", "missing")
+	if err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+	if object != nil {
+		t.Errorf("Expected no object, got %+v", object)
+	}
+}
@@ -6,6 +6,8 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
 	"git.sr.ht/~emersion/gqlclient"
@@ -20,6 +22,7 @@ type Repository struct {
 	Created     time.Time `json:"created"`
 	Updated     time.Time `json:"updated"`
 	Subject     string    `json:"subject,omitempty"`
+	Owner       User      `json:"owner"`
 }
 
 // RepositoryInput represents the input parameters for repository operations
@@ -80,6 +83,9 @@ func (c *Client) GetRepository(ctx context.Context, name string) (*Repository, e
 					visibility
 					created
 					updated
+					owner {
+						canonicalName
+					}
 				}
 			}
 		}
@@ -111,6 +117,9 @@ func (c *Client) UpdateRepository(ctx context.Context, id int, input RepositoryI
 				visibility
 				created
 				updated
+				owner {
+					canonicalName
+				}
 			}
 		}
 	`)
@@ -152,3 +161,540 @@ func (c *Client) DeleteRepository(ctx context.Context, id int) error {
 
 	return c.Git().Execute(ctx, op, nil)
 }
+
+// ACL represents an access control entry on a git repository
+type ACL struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+	Mode    string    `json:"mode"`
+	Entity  User      `json:"entity"`
+}
+
+// GetACLs retrieves all access control entries of a repository, following
+// the cursor until every page has been read. A nil slice without error is
+// returned if the repository does not exist.
+func (c *Client) GetACLs(ctx context.Context, repoID int) ([]ACL, error) {
+	acls := []ACL{}
+	var cursor *string
+	for {
+		// Variables can only be set once per operation, so every page
+		// needs a fresh one
+		op := gqlclient.NewOperation(`
+			query GetACLs($id: Int!, $cursor: Cursor) {
+				repository(id: $id) {
+					acls(cursor: $cursor) {
+						results {
+							id
+							created
+							mode
+							entity {
+								canonicalName
+							}
+						}
+						cursor
+					}
+				}
+			}
+		`)
+
+		op.Var("id", repoID)
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			Repository *struct {
+				ACLs struct {
+					Results []ACL   `json:"results"`
+					Cursor  *string `json:"cursor"`
+				} `json:"acls"`
+			} `json:"repository"`
+		}
+
+		if err := c.Git().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.Repository == nil {
+			return nil, nil
+		}
+
+		acls = append(acls, resp.Repository.ACLs.Results...)
+		if resp.Repository.ACLs.Cursor == nil {
+			return acls, nil
+		}
+		cursor = resp.Repository.ACLs.Cursor
+	}
+}
+
+// GetACL retrieves the access control entry of an entity (eg. '~example')
+// on a repository. It returns nil without error if there is none.
+func (c *Client) GetACL(ctx context.Context, repoID int, entity string) (*ACL, error) {
+	acls, err := c.GetACLs(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acl := range acls {
+		if acl.Entity.CanonicalName == entity {
+			result := acl
+			return &result, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// UpdateACL grants an entity (eg. '~example') access to a repository with
+// the given mode ("RO" or "RW"), creating or replacing its entry
+func (c *Client) UpdateACL(ctx context.Context, repoID int, mode, entity string) (*ACL, error) {
+	op := gqlclient.NewOperation(`
+		mutation UpdateACL($repoId: Int!, $mode: AccessMode!, $entity: ID!) {
+			updateACL(repoId: $repoId, mode: $mode, entity: $entity) {
+				id
+				created
+				mode
+				entity {
+					canonicalName
+				}
+			}
+		}
+	`)
+
+	op.Var("repoId", repoID)
+	op.Var("mode", mode)
+	op.Var("entity", entity)
+
+	var resp struct {
+		UpdateACL ACL `json:"updateACL"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.UpdateACL, nil
+}
+
+// DeleteACL deletes an access control entry by ID
+func (c *Client) DeleteACL(ctx context.Context, id int) error {
+	op := gqlclient.NewOperation(`
+		mutation DeleteACL($id: Int!) {
+			deleteACL(id: $id) {
+				id
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	return c.Git().Execute(ctx, op, nil)
+}
+
+// Artifact represents a file attached to a git tag
+type Artifact struct {
+	ID       int       `json:"id"`
+	Created  time.Time `json:"created"`
+	Filename string    `json:"filename"`
+	Checksum string    `json:"checksum"`
+	Size     int       `json:"size"`
+	URL      string    `json:"url"`
+}
+
+// GetArtifacts retrieves all artifacts attached to a reference (eg.
+// 'refs/tags/v1.0.0'). A nil slice without error is returned if the
+// repository or reference does not exist.
+func (c *Client) GetArtifacts(ctx context.Context, repoID int, ref string) ([]Artifact, error) {
+	var artifacts []Artifact
+	var refCursor, artifactCursor *string
+	for {
+		op := gqlclient.NewOperation(`
+			query GetArtifacts($id: Int!, $refCursor: Cursor, $artifactCursor: Cursor) {
+				repository(id: $id) {
+					references(cursor: $refCursor) {
+						results {
+							name
+							artifacts(cursor: $artifactCursor) {
+								results {
+									id
+									created
+									filename
+									checksum
+									size
+									url
+								}
+								cursor
+							}
+						}
+						cursor
+					}
+				}
+			}
+		`)
+
+		op.Var("id", repoID)
+		op.Var("refCursor", refCursor)
+		op.Var("artifactCursor", artifactCursor)
+
+		var resp struct {
+			Repository *struct {
+				References struct {
+					Results []struct {
+						Name      string `json:"name"`
+						Artifacts struct {
+							Results []Artifact `json:"results"`
+							Cursor  *string    `json:"cursor"`
+						} `json:"artifacts"`
+					} `json:"results"`
+					Cursor *string `json:"cursor"`
+				} `json:"references"`
+			} `json:"repository"`
+		}
+
+		if err := c.Git().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.Repository == nil {
+			return nil, nil
+		}
+
+		found := false
+		for _, r := range resp.Repository.References.Results {
+			if r.Name != ref {
+				continue
+			}
+			found = true
+			artifacts = append(artifacts, r.Artifacts.Results...)
+			artifactCursor = r.Artifacts.Cursor
+		}
+
+		switch {
+		case found && artifactCursor != nil:
+			// Fetch the next page of artifacts from the same page of
+			// references
+			continue
+		case found:
+			if artifacts == nil {
+				artifacts = []Artifact{}
+			}
+			return artifacts, nil
+		case resp.Repository.References.Cursor == nil:
+			return nil, nil
+		}
+		refCursor = resp.Repository.References.Cursor
+	}
+}
+
+// UploadArtifact attaches a file to the tag the revspec resolves to. The
+// file is sent as a GraphQL multipart request.
+func (c *Client) UploadArtifact(ctx context.Context, repoID int, revspec, filename string, body io.Reader) (*Artifact, error) {
+	op := gqlclient.NewOperation(`
+		mutation UploadArtifact($repoId: Int!, $revspec: String!, $file: Upload!) {
+			uploadArtifact(repoId: $repoId, revspec: $revspec, file: $file) {
+				id
+				created
+				filename
+				checksum
+				size
+				url
+			}
+		}
+	`)
+
+	op.Var("repoId", repoID)
+	op.Var("revspec", revspec)
+	op.Var("file", gqlclient.Upload{
+		Filename: filename,
+		MIMEType: "application/octet-stream",
+		Body:     body,
+	})
+
+	var resp struct {
+		UploadArtifact Artifact `json:"uploadArtifact"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.UploadArtifact, nil
+}
+
+// DeleteArtifact deletes an artifact by ID
+func (c *Client) DeleteArtifact(ctx context.Context, id int) error {
+	op := gqlclient.NewOperation(`
+		mutation DeleteArtifact($id: Int!) {
+			deleteArtifact(id: $id) {
+				id
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	return c.Git().Execute(ctx, op, nil)
+}
+
+// GitWebhook represents a webhook subscription on a git repository
+type GitWebhook struct {
+	Webhook
+	Repository struct {
+		ID int `json:"id"`
+	} `json:"repository"`
+}
+
+// CreateGitWebhook subscribes a webhook to events of a repository
+func (c *Client) CreateGitWebhook(ctx context.Context, repoID int, input WebhookInput) (*GitWebhook, error) {
+	op := gqlclient.NewOperation(`
+		mutation CreateGitWebhook($config: GitWebhookInput!) {
+			createGitWebhook(config: $config) {
+				id
+				events
+				query
+				url
+				repository {
+					id
+				}
+			}
+		}
+	`)
+
+	op.Var("config", struct {
+		RepositoryID int `json:"repositoryID"`
+		WebhookInput
+	}{repoID, input})
+
+	var resp struct {
+		CreateGitWebhook GitWebhook `json:"createGitWebhook"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.CreateGitWebhook, nil
+}
+
+// GetGitWebhook retrieves a repository webhook by ID. It returns nil
+// without error if the webhook does not exist.
+func (c *Client) GetGitWebhook(ctx context.Context, id int) (*GitWebhook, error) {
+	op := gqlclient.NewOperation(`
+		query GetGitWebhook($id: Int!) {
+			gitWebhook(id: $id) {
+				id
+				events
+				query
+				url
+				repository {
+					id
+				}
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	var resp struct {
+		GitWebhook *GitWebhook `json:"gitWebhook"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.GitWebhook, nil
+}
+
+// DeleteGitWebhook deletes a repository webhook by ID
+func (c *Client) DeleteGitWebhook(ctx context.Context, id int) error {
+	op := gqlclient.NewOperation(`
+		mutation DeleteGitWebhook($id: Int!) {
+			deleteGitWebhook(id: $id) {
+				id
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	return c.Git().Execute(ctx, op, nil)
+}
+
+// CreateGitUserWebhook subscribes a webhook to repository events of the
+// authenticated user
+func (c *Client) CreateGitUserWebhook(ctx context.Context, input WebhookInput) (*Webhook, error) {
+	op := gqlclient.NewOperation(`
+		mutation CreateUserWebhook($config: UserWebhookInput!) {
+			createUserWebhook(config: $config) {
+				id
+				events
+				query
+				url
+			}
+		}
+	`)
+
+	op.Var("config", input)
+
+	var resp struct {
+		CreateUserWebhook Webhook `json:"createUserWebhook"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.CreateUserWebhook, nil
+}
+
+// GetGitUserWebhook retrieves a user webhook of git.sr.ht by ID. It returns
+// nil without error if the webhook does not exist.
+func (c *Client) GetGitUserWebhook(ctx context.Context, id int) (*Webhook, error) {
+	op := gqlclient.NewOperation(`
+		query GetUserWebhook($id: Int!) {
+			userWebhook(id: $id) {
+				id
+				events
+				query
+				url
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	var resp struct {
+		UserWebhook *Webhook `json:"userWebhook"`
+	}
+
+	if err := c.Git().Execute(ctx, op, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.UserWebhook, nil
+}
+
+// GetGitUserWebhooks retrieves all user webhooks of git.sr.ht, following the
+// cursor until every page has been read
+func (c *Client) GetGitUserWebhooks(ctx context.Context) ([]Webhook, error) {
+	hooks := []Webhook{}
+	var cursor *string
+	for {
+		op := gqlclient.NewOperation(`
+			query GetUserWebhooks($cursor: Cursor) {
+				userWebhooks(cursor: $cursor) {
+					results {
+						id
+						events
+						query
+						url
+					}
+					cursor
+				}
+			}
+		`)
+
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			UserWebhooks struct {
+				Results []Webhook `json:"results"`
+				Cursor  *string   `json:"cursor"`
+			} `json:"userWebhooks"`
+		}
+
+		if err := c.Git().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		hooks = append(hooks, resp.UserWebhooks.Results...)
+		if resp.UserWebhooks.Cursor == nil {
+			return hooks, nil
+		}
+		cursor = resp.UserWebhooks.Cursor
+	}
+}
+
+// DeleteGitUserWebhook deletes a user webhook of git.sr.ht by ID
+func (c *Client) DeleteGitUserWebhook(ctx context.Context, id int) error {
+	op := gqlclient.NewOperation(`
+		mutation DeleteUserWebhook($id: Int!) {
+			deleteUserWebhook(id: $id) {
+				id
+			}
+		}
+	`)
+
+	op.Var("id", id)
+
+	return c.Git().Execute(ctx, op, nil)
+}
+
+// userQuery returns the selection of a user by username, or of the
+// authenticated user if username is empty, aliased as "user", along with the
+// variable definition it requires
+func userQuery(username string) (selection, vars string) {
+	if username == "" {
+		return "user: me", ""
+	}
+	return "user(username: $username)", ", $username: String!"
+}
+
+// GetRepositories retrieves all repositories of a user by username, or of
+// the authenticated user if username is empty, following the cursor until
+// every page has been read
+func (c *Client) GetRepositories(ctx context.Context, username string) ([]Repository, error) {
+	selection, vars := userQuery(username)
+
+	repos := []Repository{}
+	var cursor *string
+	for {
+		op := gqlclient.NewOperation(fmt.Sprintf(`
+			query GetRepos($cursor: Cursor%s) {
+				%s {
+					repositories(cursor: $cursor) {
+						results {
+							id
+							name
+							description
+							visibility
+							created
+							updated
+							owner {
+								canonicalName
+							}
+						}
+						cursor
+					}
+				}
+			}
+		`, vars, selection))
+
+		if username != "" {
+			op.Var("username", username)
+		}
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			User *struct {
+				Repositories struct {
+					Results []Repository `json:"results"`
+					Cursor  *string      `json:"cursor"`
+				} `json:"repositories"`
+			} `json:"user"`
+		}
+
+		if err := c.Git().Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.User == nil {
+			return nil, fmt.Errorf("user %s not found", username)
+		}
+
+		repos = append(repos, resp.User.Repositories.Results...)
+		if resp.User.Repositories.Cursor == nil {
+			return repos, nil
+		}
+		cursor = resp.User.Repositories.Cursor
+	}
+}
@@ -7,8 +7,10 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"git.sr.ht/~emersion/gqlclient"
@@ -91,3 +93,157 @@ func TestCreateRepository(t *testing.T) {
 		t.Errorf("Expected repo visibility %s, got %s", input.Visibility, repo.Visibility)
 	}
 }
+
+func TestGetACLs(t *testing.T) {
+	// Mock server returning two pages of ACLs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if id := req.Variables["id"].(float64); id != 1 {
+			t.Errorf("Expected repository id 1, got %v", id)
+		}
+
+		var acls map[string]interface{}
+		switch req.Variables["cursor"] {
+		case nil:
+			acls = map[string]interface{}{
+				"results": []interface{}{
+					map[string]interface{}{
+						"id":      10,
+						"created": "2025-10-28T12:00:00Z",
+						"mode":    "RO",
+						"entity":  map[string]interface{}{"canonicalName": "~alice"},
+					},
+				},
+				"cursor": "next",
+			}
+		case "next":
+			acls = map[string]interface{}{
+				"results": []interface{}{
+					map[string]interface{}{
+						"id":      11,
+						"created": "2025-10-28T12:00:00Z",
+						"mode":    "RW",
+						"entity":  map[string]interface{}{"canonicalName": "~bob"},
+					},
+				},
+				"cursor": nil,
+			}
+		default:
+			t.Fatalf("Unexpected cursor %v", req.Variables["cursor"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"repository": map[string]interface{}{"acls": acls},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			GitService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	acl, err := c.GetACL(context.Background(), 1, "~bob")
+	if err != nil {
+		t.Fatalf("Failed to get ACL: %v", err)
+	}
+	if acl == nil || acl.ID != 11 || acl.Mode != "RW" {
+		t.Errorf("Expected ACL 11 with mode RW, got %+v", acl)
+	}
+
+	acl, err = c.GetACL(context.Background(), 1, "~carol")
+	if err != nil {
+		t.Fatalf("Failed to get ACL: %v", err)
+	}
+	if acl != nil {
+		t.Errorf("Expected no ACL for ~carol, got %+v", acl)
+	}
+}
+
+func TestUploadArtifact(t *testing.T) {
+	// Mock server expecting a GraphQL multipart request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("Expected multipart request: %v", err)
+		}
+
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.Unmarshal([]byte(r.FormValue("operations")), &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.Variables["revspec"] != "v1.0.0" {
+			t.Errorf("Expected revspec v1.0.0, got %v", req.Variables["revspec"])
+		}
+
+		var fileMap map[string][]string
+		if err := json.Unmarshal([]byte(r.FormValue("map")), &fileMap); err != nil {
+			t.Fatal(err)
+		}
+		if len(fileMap["file"]) != 1 || fileMap["file"][0] != "variables.file" {
+			t.Errorf("Expected file mapped to variables.file, got %v", fileMap)
+		}
+
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+		body, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"uploadArtifact": map[string]interface{}{
+					"id":       1,
+					"created":  "2025-10-28T12:00:00Z",
+					"filename": header.Filename,
+					"checksum": "sha256:abc",
+					"size":     len(body),
+					"url":      "https://git.sr.ht/~example/repo/refs/download/v1.0.0/" + header.Filename,
+				},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			GitService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	artifact, err := c.UploadArtifact(context.Background(), 1, "v1.0.0",
+		"release.tar.gz", strings.NewReader("contents"))
+	if err != nil {
+		t.Fatalf("Failed to upload artifact: %v", err)
+	}
+
+	if artifact.Filename != "release.tar.gz" {
+		t.Errorf("Expected filename release.tar.gz, got %s", artifact.Filename)
+	}
+	if artifact.Size != len("contents") {
+		t.Errorf("Expected size %d, got %d", len("contents"), artifact.Size)
+	}
+}
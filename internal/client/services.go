@@ -10,6 +10,8 @@ type Service string
 const (
 	// GitService represents git.sr.ht
 	GitService Service = "git.sr.ht"
+	// HgService represents hg.sr.ht
+	HgService Service = "hg.sr.ht"
 	// MetaService represents meta.sr.ht
 	MetaService Service = "meta.sr.ht"
 	// PasteService represents paste.sr.ht
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package client
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"git.sr.ht/~emersion/gqlclient"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Webhook represents a GraphQL webhook subscription of a sourcehut service
+type Webhook struct {
+	ID     int      `json:"id"`
+	Events []string `json:"events"`
+	Query  string   `json:"query"`
+	URL    string   `json:"url"`
+}
+
+// WebhookInput represents the input parameters for webhook operations
+type WebhookInput struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Query  string   `json:"query"`
+}
+
+//go:embed schema/*.graphqls
+var schemaFiles embed.FS
+
+var (
+	schemasMu sync.Mutex
+	schemas   = map[Service]*ast.Schema{}
+)
+
+// loadSchema parses the embedded GraphQL schema of a service once
+func loadSchema(service Service) (*ast.Schema, error) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	if s, ok := schemas[service]; ok {
+		return s, nil
+	}
+
+	// The schema of git.sr.ht is embedded as schema/git.graphqls
+	prefix, _, _ := strings.Cut(string(service), ".")
+	name := fmt.Sprintf("schema/%s.graphqls", prefix)
+	b, err := schemaFiles.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("no GraphQL schema available for %s", service)
+	}
+
+	s, err := gqlparser.LoadSchema(&ast.Source{Name: name, Input: string(b)})
+	if err != nil {
+		return nil, fmt.Errorf("error loading GraphQL schema of %s: %w", service, err)
+	}
+	schemas[service] = s
+
+	return s, nil
+}
+
+// schemaGapRules are the validation rules that fail on fields, arguments
+// and types the embedded schemas may lack, eg. after an upstream addition.
+var schemaGapRules = map[string]bool{
+	"FieldsOnCorrectType": true,
+	"KnownArgumentNames":  true,
+	"KnownTypeNames":      true,
+}
+
+// ValidateWebhookQuery checks a webhook payload query against the GraphQL
+// schema of a service. The query has to be a single query operation.
+// Unknown fields, arguments and types are returned as warnings instead of
+// errors, as the embedded schema only covers part of the upstream one.
+func ValidateWebhookQuery(service Service, query string) ([]string, error) {
+	s, err := loadSchema(service)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload query for %s: %w", service, err)
+	}
+
+	var warnings []string
+	var invalid gqlerror.List
+	for _, e := range validator.ValidateWithRules(s, doc, nil) {
+		if schemaGapRules[e.Rule] {
+			warnings = append(warnings, fmt.Sprintf("payload query for %s may be invalid: %s", service, e.Message))
+		} else {
+			invalid = append(invalid, e)
+		}
+	}
+	if len(invalid) > 0 {
+		return warnings, fmt.Errorf("invalid payload query for %s: %w", service, invalid)
+	}
+
+	if len(doc.Operations) != 1 {
+		return warnings, fmt.Errorf("payload query must contain exactly one operation, got %d", len(doc.Operations))
+	}
+	if doc.Operations[0].Operation != ast.Query {
+		return warnings, fmt.Errorf("payload query must be a query, got a %s", doc.Operations[0].Operation)
+	}
+
+	return warnings, nil
+}
+
+// WebhookDelivery represents a single delivery of a webhook payload
+type WebhookDelivery struct {
+	UUID           string    `json:"uuid"`
+	Date           time.Time `json:"date"`
+	Event          string    `json:"event"`
+	RequestBody    string    `json:"requestBody"`
+	ResponseBody   *string   `json:"responseBody"`
+	ResponseStatus int       `json:"responseStatus"`
+}
+
+// webhookFields maps the kinds of webhooks of every service to the query
+// field retrieving a webhook of that kind by ID
+var webhookFields = map[Service]map[string]string{
+	GitService: {
+		"user":       "userWebhook",
+		"repository": "gitWebhook",
+	},
+	HgService: {
+		"user": "userWebhook",
+	},
+	MetaService: {
+		"user": "profileWebhook",
+	},
+	PasteService: {
+		"user": "userWebhook",
+	},
+}
+
+// GetWebhookDeliveries retrieves up to limit of the latest deliveries of a
+// webhook of the given kind ("user" or "repository") on a service,
+// following the cursor until enough pages have been read. A nil slice
+// without error is returned if the webhook does not exist.
+func (c *Client) GetWebhookDeliveries(ctx context.Context, service Service, kind string, id, limit int) ([]WebhookDelivery, error) {
+	field, ok := webhookFields[service][kind]
+	if !ok {
+		return nil, fmt.Errorf("%s does not support %s webhooks", service, kind)
+	}
+
+	deliveries := []WebhookDelivery{}
+	var cursor *string
+	for {
+		op := gqlclient.NewOperation(fmt.Sprintf(`
+			query GetWebhookDeliveries($id: Int!, $cursor: Cursor) {
+				webhook: %s(id: $id) {
+					deliveries(cursor: $cursor) {
+						results {
+							uuid
+							date
+							event
+							requestBody
+							responseBody
+							responseStatus
+						}
+						cursor
+					}
+				}
+			}
+		`, field))
+
+		op.Var("id", id)
+		op.Var("cursor", cursor)
+
+		var resp struct {
+			Webhook *struct {
+				Deliveries struct {
+					Results []WebhookDelivery `json:"results"`
+					Cursor  *string           `json:"cursor"`
+				} `json:"deliveries"`
+			} `json:"webhook"`
+		}
+
+		if err := c.getClient(service).Execute(ctx, op, &resp); err != nil {
+			return nil, err
+		}
+
+		if resp.Webhook == nil {
+			return nil, nil
+		}
+
+		deliveries = append(deliveries, resp.Webhook.Deliveries.Results...)
+		if len(deliveries) >= limit {
+			return deliveries[:limit], nil
+		}
+		if resp.Webhook.Deliveries.Cursor == nil {
+			return deliveries, nil
+		}
+		cursor = resp.Webhook.Deliveries.Cursor
+	}
+}
@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git.sr.ht/~emersion/gqlclient"
+)
+
+func TestValidateWebhookQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  Service
+		query    string
+		wantErr  string
+		wantWarn string
+	}{
+		{
+			name:    "git event",
+			service: GitService,
+			query: `query {
+				webhook {
+					uuid
+					event
+					date
+					... on GitEvent {
+						repository { id name owner { canonicalName } }
+						pusher { canonicalName }
+						updates {
+							ref { name }
+							old { id }
+							new { id shortId }
+						}
+					}
+				}
+			}`,
+		},
+		{
+			name:    "meta key event",
+			service: MetaService,
+			query: `query {
+				webhook {
+					event
+					... on SSHKeyEvent { key { fingerprint comment user { canonicalName } } }
+					... on PGPKeyEvent { key { fingerprint } }
+				}
+			}`,
+		},
+		{
+			name:    "meta profile event",
+			service: MetaService,
+			query: `query {
+				webhook {
+					... on ProfileUpdateEvent { profile { email pgpKey { fingerprint } } }
+				}
+			}`,
+		},
+		{
+			name:     "meta unknown field",
+			service:  MetaService,
+			query:    `query { webhook { uuid repository { id } } }`,
+			wantWarn: `Cannot query field "repository" on type "WebhookPayload"`,
+		},
+		{
+			name:     "unknown field",
+			service:  GitService,
+			query:    `query { webhook { uuid pusher { canonicalName } } }`,
+			wantWarn: `Cannot query field "pusher" on type "WebhookPayload"`,
+		},
+		{
+			name:     "unknown type",
+			service:  GitService,
+			query:    `query { webhook { uuid ... on FutureEvent { id } } }`,
+			wantWarn: `Unknown type "FutureEvent"`,
+		},
+		{
+			name:    "syntax error",
+			service: GitService,
+			query:   `query { webhook { uuid }`,
+			wantErr: "invalid payload query",
+		},
+		{
+			name:    "mutation",
+			service: GitService,
+			query:   `mutation { webhook { uuid } }`,
+			wantErr: "invalid payload query",
+		},
+		{
+			name:    "multiple operations",
+			service: GitService,
+			query:   `query A { webhook { uuid } } query B { webhook { date } }`,
+			wantErr: "exactly one operation",
+		},
+		{
+			name:    "unknown service",
+			service: Service("example.sr.ht"),
+			query:   `query { webhook { uuid } }`,
+			wantErr: "no GraphQL schema available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := ValidateWebhookQuery(tt.service, tt.query)
+			switch {
+			case tt.wantWarn == "" && len(warnings) > 0:
+				t.Errorf("unexpected warnings: %v", warnings)
+			case tt.wantWarn != "" && !strings.Contains(strings.Join(warnings, "\n"), tt.wantWarn):
+				t.Errorf("expected warning containing %q, got %v", tt.wantWarn, warnings)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("expected error containing %q, got none", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCreateGitUserWebhook(t *testing.T) {
+	// Mock server echoing the webhook config
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Config map[string]interface{} `json:"config"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		hook := req.Variables.Config
+		hook["id"] = 7
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{"createUserWebhook": hook},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			GitService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	input := WebhookInput{
+		URL:    "https://example.org/hook",
+		Events: []string{"REPO_CREATED", "REPO_DELETED"},
+		Query:  "query { webhook { uuid } }",
+	}
+
+	hook, err := c.CreateGitUserWebhook(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+
+	want := &Webhook{ID: 7, URL: input.URL, Events: input.Events, Query: input.Query}
+	if !reflect.DeepEqual(hook, want) {
+		t.Errorf("Expected webhook %+v, got %+v", want, hook)
+	}
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	// Mock server returning pages of two deliveries
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(req.Query, "webhook: gitWebhook(id: $id)") {
+			t.Errorf("Expected query of a repository webhook, got %s", req.Query)
+		}
+
+		pages++
+		delivery := func(uuid string) map[string]interface{} {
+			return map[string]interface{}{
+				"uuid":           uuid,
+				"date":           "2026-01-02T12:00:00Z",
+				"event":          "GIT_POST_RECEIVE",
+				"requestBody":    "{}",
+				"responseBody":   nil,
+				"responseStatus": -1,
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{
+			"data": map[string]interface{}{
+				"webhook": map[string]interface{}{
+					"deliveries": map[string]interface{}{
+						"results": []interface{}{
+							delivery(fmt.Sprintf("%d-a", pages)),
+							delivery(fmt.Sprintf("%d-b", pages)),
+						},
+						"cursor": "next",
+					},
+				},
+			},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	c := &Client{
+		clients: map[Service]*gqlclient.Client{
+			GitService: gqlclient.New(server.URL, http.DefaultClient),
+		},
+	}
+
+	deliveries, err := c.GetWebhookDeliveries(context.Background(), GitService, "repository", 1, 3)
+	if err != nil {
+		t.Fatalf("Failed to get deliveries: %v", err)
+	}
+	if pages != 2 {
+		t.Errorf("Expected 2 pages to be read, got %d", pages)
+	}
+	if len(deliveries) != 3 || deliveries[2].UUID != "2-a" {
+		t.Errorf("Expected 3 deliveries ending with 2-a, got %+v", deliveries)
+	}
+
+	if _, err := c.GetWebhookDeliveries(context.Background(), MetaService, "repository", 1, 3); err == nil {
+		t.Error("Expected an error for repository webhooks on meta.sr.ht")
+	}
+}
@@ -6,7 +6,13 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 )
 
 // Build time configuration.
@@ -15,9 +21,33 @@ var (
 	Commit  = ""
 )
 
+// providerAddress is the registry address of the provider.
+const providerAddress = "registry.terraform.io/wombelix/sourcehut"
+
+// providerServer muxes the SDK provider with the plugin framework provider,
+// which serves the ephemeral resources.
+func providerServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		provider().GRPCProvider,
+		providerserver.NewProtocol5(newFrameworkProvider()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: provider})
+	ctx := context.Background()
+
+	server, err := providerServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tf5server.Serve(providerAddress, server); err != nil {
+		log.Fatal(err)
+	}
 }
 
 // Generate documentation for TF registry
@@ -41,6 +41,14 @@ const (
 	gitURLKey = "git_url"
 	gitURLEnv = "SRHT_GIT_URL"
 	gitURLDef = "https://git.sr.ht/api"
+
+	// Hg config
+	hgURLKey = "hg_url"
+	hgURLEnv = "SRHT_HG_URL"
+	hgURLDef = "https://hg.sr.ht/api"
+
+	// Shared with the repository resource
+	deletionProtectionKey = "deletion_protection"
 )
 
 func provider() *schema.Provider {
@@ -67,15 +75,25 @@ func provider() *schema.Provider {
 					pasteURLEnv),
 			},
 			gitURLKey: {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  gitURLDef,
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(gitURLEnv, gitURLDef),
 				Description: fmt.Sprintf(
 					`The URL to the SourceHut Git API endpoint. It is required if using
 					a private installation of SourceHut. The default is to use the cloud
 					git service. It can be provided via the %s environment variable.`,
 					gitURLEnv),
 			},
+			hgURLKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(hgURLEnv, hgURLDef),
+				Description: fmt.Sprintf(
+					`The URL to the SourceHut Mercurial API endpoint. It is required if
+					using a private installation of SourceHut. The default is to use the
+					cloud hg service. It can be provided via the %s environment variable.`,
+					hgURLEnv),
+			},
 			tokenKey: {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -84,17 +102,46 @@ func provider() *schema.Provider {
 					resources. It can be provided via the %s environment variable.`,
 					tokenEnv),
 			},
+			deletionProtectionKey: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `The default for deletion_protection of repositories that don't
+					set it explicitly.`,
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			sshKeyName: resourceSSHKey(),
-			pgpKeyName: resourcePGPKey(),
-			repoName:   resourceRepo(),
+			sshKeyName:          resourceSSHKey(),
+			sshKeysName:         resourceSSHKeys(),
+			pgpKeyName:          resourcePGPKey(),
+			pgpKeysName:         resourcePGPKeys(),
+			repoName:            resourceRepo(),
+			repoACLName:         resourceRepoACL(),
+			repoACLsName:        resourceRepoACLs(),
+			repoArtifactName:    resourceRepoArtifact(),
+			repoWebhookName:     resourceRepoWebhook(),
+			gitUserWebhookName:  resourceGitUserWebhook(),
+			metaWebhookName:     resourceMetaWebhook(),
+			oauthClientName:     resourceOAuthClient(),
+			hgRepoName:          resourceHgRepo(),
+			userProfileName:     resourceUserProfile(),
+			preferredPGPKeyName: resourcePreferredPGPKey(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			pasteName: dataSourcePaste(),
-			blobName:  dataSourceBlob(),
-			userName:  dataSourceUser(),
-			repoName:  dataSourceRepo(),
+			pasteName:             dataSourcePaste(),
+			blobName:              dataSourceBlob(),
+			userName:              dataSourceUser(),
+			repoName:              dataSourceRepo(),
+			reposName:             dataSourceRepos(),
+			repoFileName:          dataSourceRepoFile(),
+			repoRefsName:          dataSourceRepoRefs(),
+			repoLogName:           dataSourceRepoLog(),
+			repoTreeName:          dataSourceRepoTree(),
+			tagVerificationName:   dataSourceTagVerification(),
+			gitUserWebhooksName:   dataSourceGitUserWebhooks(),
+			webhookDeliveriesName: dataSourceWebhookDeliveries(),
+			hgRepoName:            dataSourceHgRepo(),
+			auditLogName:          dataSourceAuditLog(),
 		},
 		ConfigureFunc: configureProvider,
 	}
@@ -109,7 +156,10 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	}
 
 	return &config{
-		client: c,
+		client:             c,
+		gitURL:             d.Get(gitURLKey).(string),
+		hgURL:              d.Get(hgURLKey).(string),
+		deletionProtection: d.Get(deletionProtectionKey).(bool),
 	}, nil
 }
 
@@ -117,6 +167,18 @@ type config struct {
 	client *client.Client
 	// We keep client as a single instance to handle all services
 	// instead of having separate clients for each service
+
+	// gitURL is the configured git.sr.ht API endpoint, used to derive web
+	// and clone URLs for repositories.
+	gitURL string
+
+	// hgURL is the configured hg.sr.ht API endpoint, used to derive web and
+	// clone URLs for mercurial repositories.
+	hgURL string
+
+	// deletionProtection is the default for repositories that don't
+	// configure it.
+	deletionProtection bool
 }
 
 func dataOrEnv(d *schema.ResourceData, key, env string) string {
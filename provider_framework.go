@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider serves the parts of the provider that are only available
+// with the plugin framework, like ephemeral resources. It is muxed with the
+// SDK provider and shares its configuration.
+type frameworkProvider struct{}
+
+var _ fwprovider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+func newFrameworkProvider() fwprovider.Provider {
+	return &frameworkProvider{}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "sourcehut"
+	resp.Version = Version
+}
+
+// Schema mirrors the schema of the SDK provider, both have to be identical
+// to be muxed.
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	attrs := make(map[string]fwschema.Attribute)
+	for key, s := range provider().Schema {
+		switch s.Type {
+		case schema.TypeBool:
+			attrs[key] = fwschema.BoolAttribute{
+				Optional:    s.Optional,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		default:
+			attrs[key] = fwschema.StringAttribute{
+				Optional:    s.Optional,
+				Sensitive:   s.Sensitive,
+				Description: s.Description,
+			}
+		}
+	}
+	resp.Schema = fwschema.Schema{Attributes: attrs}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	var token types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(tokenKey), &token)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	t := token.ValueString()
+	if t == "" {
+		t = os.Getenv(tokenEnv)
+	}
+
+	c, err := client.NewClient(t)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating sourcehut client", err.Error())
+		return
+	}
+
+	resp.EphemeralResourceData = &config{client: c}
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newPersonalAccessTokenEphemeralResource,
+	}
+}
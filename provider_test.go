@@ -5,8 +5,16 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func init() {
@@ -16,8 +24,95 @@ func init() {
 	}
 }
 
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// testConfig returns a provider configuration whose client answers every
+// GraphQL request with handle instead of sourcehut. handle is called with the
+// host of the service, the query and its variables and returns the data of
+// the response, or an error to fail the request with.
+func testConfig(t *testing.T, handle func(host, query string, vars map[string]interface{}) interface{}) *config {
+	transport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, err
+		}
+
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "application/json")
+		resp := map[string]interface{}{"data": handle(r.URL.Host, req.Query, req.Variables)}
+		if err, ok := resp["data"].(error); ok {
+			resp = map[string]interface{}{
+				"errors": []interface{}{map[string]interface{}{"message": err.Error()}},
+			}
+		}
+		if err := json.NewEncoder(rec).Encode(resp); err != nil {
+			return nil, err
+		}
+		return rec.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	c, err := client.NewClient("test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config{client: c, gitURL: gitURLDef, hgURL: hgURLDef}
+}
+
 func TestProvider(t *testing.T) {
 	if err := provider().InternalValidate(); err != nil {
 		t.Error(err)
 	}
 }
+
+func TestProviderServer(t *testing.T) {
+	ctx := context.Background()
+
+	// Muxing fails if the SDK and framework provider schemas differ
+	server, err := providerServer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+
+	if _, ok := resp.EphemeralResourceSchemas[personalAccessTokenName]; !ok {
+		t.Errorf("Expected ephemeral resource %s to be served", personalAccessTokenName)
+	}
+	if _, ok := resp.ResourceSchemas[repoName]; !ok {
+		t.Errorf("Expected resource %s to be served", repoName)
+	}
+}
+
+func TestConfigureProviderURLEnv(t *testing.T) {
+	t.Setenv(tokenEnv, "test-token")
+	t.Setenv(gitURLEnv, "https://git.example.org/api")
+	t.Setenv(hgURLEnv, "https://hg.example.org/api")
+
+	p := provider()
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		t.Fatalf("Failed to configure provider: %v", diags)
+	}
+
+	config := p.Meta().(*config)
+	if config.gitURL != "https://git.example.org/api" {
+		t.Errorf("Expected git URL from %s, got %s", gitURLEnv, config.gitURL)
+	}
+	if config.hgURL != "https://hg.example.org/api" {
+		t.Errorf("Expected hg URL from %s, got %s", hgURLEnv, config.hgURL)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Resource Name
+	gitUserWebhookName = "sourcehut_git_user_webhook"
+)
+
+// gitUserWebhookEvents are the events a user webhook of git.sr.ht can
+// subscribe to.
+var gitUserWebhookEvents = []string{"REPO_CREATED", "REPO_UPDATE", "REPO_DELETED"}
+
+func resourceGitUserWebhook() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGitUserWebhookCreate,
+		ReadContext:   resourceGitUserWebhookRead,
+		DeleteContext: resourceGitUserWebhookDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: webhookSchema(client.GitService, gitUserWebhookEvents),
+	}
+}
+
+func resourceGitUserWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	hook, err := config.client.CreateGitUserWebhook(ctx, webhookInput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(hook.ID))
+
+	if err := setWebhook(d, hook); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceGitUserWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid webhook id %q", d.Id())
+	}
+
+	hook, err := config.client.GetGitUserWebhook(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if hook == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := setWebhook(d, hook); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceGitUserWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	id, _ := strconv.Atoi(d.Id())
+	if err := config.client.DeleteGitUserWebhook(ctx, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+)
+
+const (
+	// Resource Name
+	hgRepoName = "sourcehut_hg_repository"
+
+	// Schema keys
+	readmeKey        = "readme"
+	nonPublishingKey = "non_publishing"
+)
+
+// hgRepoSchema returns a schema that is used by both the mercurial repo
+// resource and the mercurial repo datasource.
+func hgRepoSchema() map[string]*schema.Schema {
+	s := baseRepoSchema()
+	s[readmeKey] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: `Custom HTML shown instead of the README file of the repository.
+			Empty to render the README file.`,
+	}
+	s[nonPublishingKey] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether the repository is non-publishing, ie. pushed changesets stay in the draft phase.",
+	}
+	return s
+}
+
+func resourceHgRepo() *schema.Resource {
+	s := hgRepoSchema()
+	addRepoGuardSchema(s)
+
+	return &schema.Resource{
+		Create: resourceHgRepoCreate,
+		Read:   resourceHgRepoRead,
+		Delete: resourceHgRepoDelete,
+		Update: resourceHgRepoUpdate,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceHgRepoImport,
+		},
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf(webURLKey, repoNameChanged),
+			customdiff.ComputedIf(httpsCloneURLKey, repoNameChanged),
+			customdiff.ComputedIf(sshCloneURLKey, repoNameChanged),
+			repoDeletionProtectionDefault,
+			repoVisibilityGuard,
+		),
+		Schema: s,
+	}
+}
+
+func resourceHgRepoCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config)
+	input := client.HgRepositoryInput{
+		Name:          d.Get(nameKey).(string),
+		Description:   d.Get(descKey).(string),
+		Visibility:    strings.ToUpper(d.Get(visiKey).(string)),
+		Readme:        d.Get(readmeKey).(string),
+		NonPublishing: d.Get(nonPublishingKey).(bool),
+	}
+
+	repo, err := config.client.CreateHgRepository(context.Background(), input)
+	if err != nil {
+		return err
+	}
+
+	// The readme and publishing phase can only be set on update
+	if input.Readme != "" || input.NonPublishing {
+		d.SetId(strconv.Itoa(repo.ID))
+		input.Name = ""
+		repo, err = config.client.UpdateHgRepository(context.Background(), repo.ID, input)
+		if err != nil {
+			return err
+		}
+	}
+
+	return setHgRepo(d, config.hgURL, repo)
+}
+
+func resourceHgRepoRead(d *schema.ResourceData, meta interface{}) error {
+	return hgRepoRead(d, meta, false)
+}
+
+func hgRepoRead(d *schema.ResourceData, meta interface{}, importing bool) error {
+	config := meta.(*config)
+
+	name := d.Id()
+	if !importing {
+		name = d.Get(nameKey).(string)
+	}
+
+	repo, err := config.client.GetHgRepository(context.Background(), name)
+	if err != nil {
+		return err
+	}
+
+	if repo == nil {
+		if importing {
+			return fmt.Errorf("mercurial repository %s not found", name)
+		}
+		d.SetId("")
+		return nil
+	}
+
+	return setHgRepo(d, config.hgURL, repo)
+}
+
+func resourceHgRepoDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config)
+
+	if err := repoDeletionCheck(d); err != nil {
+		return err
+	}
+
+	id, _ := strconv.Atoi(d.Id())
+	return config.client.DeleteHgRepository(context.Background(), id)
+}
+
+func resourceHgRepoUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config)
+
+	// deletion_protection and allow_publish only live in the state
+	if !d.HasChanges(nameKey, descKey, visiKey, readmeKey, nonPublishingKey) {
+		return nil
+	}
+
+	id, _ := strconv.Atoi(d.Id())
+	oldName, newName := d.GetChange(nameKey)
+
+	input := client.HgRepositoryInput{
+		Description:   d.Get(descKey).(string),
+		Visibility:    strings.ToUpper(d.Get(visiKey).(string)),
+		Readme:        d.Get(readmeKey).(string),
+		NonPublishing: d.Get(nonPublishingKey).(bool),
+	}
+
+	if oldName.(string) != newName.(string) {
+		input.Name = newName.(string)
+	}
+
+	repo, err := config.client.UpdateHgRepository(context.Background(), id, input)
+	if err != nil {
+		return err
+	}
+
+	return setHgRepo(d, config.hgURL, repo)
+}
+
+func resourceHgRepoImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	err := hgRepoRead(d, meta, true)
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set(deletionProtectionKey, meta.(*config).deletionProtection)
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set(allowPublishKey, false)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// hgRepoURLs derives the web, HTTPS clone and SSH clone URLs of a mercurial
+// repository from the hg.sr.ht API endpoint, eg. "https://hg.sr.ht/api"
+// yields "https://hg.sr.ht/~example/repo" and
+// "ssh://hg@hg.sr.ht/~example/repo".
+func hgRepoURLs(hgURL, owner, name string) (web, https, ssh string, err error) {
+	web, https, _, err = repoURLs(hgURL, owner, name)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	u, _ := url.Parse(hgURL)
+	return web, https, fmt.Sprintf("ssh://hg@%s/%s/%s", u.Hostname(), owner, name), nil
+}
+
+func setHgRepo(d *schema.ResourceData, hgURL string, repo *client.HgRepository) error {
+	d.SetId(strconv.Itoa(repo.ID))
+	err := d.Set(createdKey, repo.Created.Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	err = d.Set(createdTimestampKey, repo.Created.Unix())
+	if err != nil {
+		return err
+	}
+	err = d.Set(descKey, repo.Description)
+	if err != nil {
+		return err
+	}
+	err = d.Set(visiKey, repo.Visibility)
+	if err != nil {
+		return err
+	}
+	err = d.Set(readmeKey, repo.Readme)
+	if err != nil {
+		return err
+	}
+	err = d.Set(nonPublishingKey, repo.NonPublishing)
+	if err != nil {
+		return err
+	}
+	err = d.Set(ownerKey, repo.Owner.CanonicalName)
+	if err != nil {
+		return err
+	}
+
+	web, https, ssh, err := hgRepoURLs(hgURL, repo.Owner.CanonicalName, repo.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set(webURLKey, web)
+	if err != nil {
+		return err
+	}
+	err = d.Set(httpsCloneURLKey, https)
+	if err != nil {
+		return err
+	}
+	err = d.Set(sshCloneURLKey, ssh)
+	if err != nil {
+		return err
+	}
+	return d.Set(nameKey, repo.Name)
+}
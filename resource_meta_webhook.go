@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Resource Name
+	metaWebhookName = "sourcehut_meta_webhook"
+)
+
+// metaWebhookEvents are the events a profile webhook of meta.sr.ht can
+// subscribe to.
+var metaWebhookEvents = []string{
+	"PROFILE_UPDATE",
+	"PGP_KEY_ADDED",
+	"PGP_KEY_REMOVED",
+	"SSH_KEY_ADDED",
+	"SSH_KEY_REMOVED",
+}
+
+func resourceMetaWebhook() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMetaWebhookCreate,
+		ReadContext:   resourceMetaWebhookRead,
+		DeleteContext: resourceMetaWebhookDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: webhookSchema(client.MetaService, metaWebhookEvents),
+	}
+}
+
+func resourceMetaWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	hook, err := config.client.CreateMetaWebhook(ctx, webhookInput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(hook.ID))
+
+	if err := setWebhook(d, hook); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceMetaWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid webhook id %q", d.Id())
+	}
+
+	hook, err := config.client.GetMetaWebhook(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if hook == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := setWebhook(d, hook); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceMetaWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	id, _ := strconv.Atoi(d.Id())
+	if err := config.client.DeleteMetaWebhook(ctx, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Resource Name
+	oauthClientName = "sourcehut_oauth_client"
+
+	// Schema keys
+	redirectURIKey  = "redirect_uri"
+	clientIDKey     = "client_id"
+	clientSecretKey = "client_secret"
+)
+
+func resourceOAuthClient() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOAuthClientCreate,
+		ReadContext:   resourceOAuthClientRead,
+		DeleteContext: resourceOAuthClientDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			nameKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the client, shown to users when they authorize it.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			redirectURIKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The URI users are redirected to after authorizing the client.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			descKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A description of the client, shown to users when they authorize it.",
+			},
+			urlKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The URL of the clients website.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			clientIDKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The client ID.",
+			},
+			clientSecretKey: {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+				Description: `The client secret. sourcehut only returns it when the client is
+					registered, it is empty for imported clients.`,
+			},
+		},
+	}
+}
+
+func resourceOAuthClientCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	oauthClient, secret, err := config.client.RegisterOAuthClient(ctx, client.OAuthClientInput{
+		RedirectURI: d.Get(redirectURIKey).(string),
+		Name:        d.Get(nameKey).(string),
+		Description: d.Get(descKey).(string),
+		URL:         d.Get(urlKey).(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(oauthClient.UUID)
+
+	// The secret can't be read back, it is only kept in the state
+	if err := d.Set(clientSecretKey, secret); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting client secret key: %s", err))
+	}
+
+	if err := setOAuthClient(d, oauthClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceOAuthClientRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	oauthClient, err := config.client.GetOAuthClient(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if oauthClient == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := setOAuthClient(d, oauthClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceOAuthClientDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	if err := config.client.RevokeOAuthClient(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// setOAuthClient sets the arguments and the client ID of an OAuth client.
+// The client secret is left alone, as it is never returned again.
+func setOAuthClient(d *schema.ResourceData, oauthClient *client.OAuthClient) error {
+	if err := d.Set(clientIDKey, oauthClient.UUID); err != nil {
+		return fmt.Errorf("error setting client id key: %s", err)
+	}
+
+	if err := d.Set(nameKey, oauthClient.Name); err != nil {
+		return fmt.Errorf("error setting name key: %s", err)
+	}
+
+	if err := d.Set(redirectURIKey, oauthClient.RedirectURL); err != nil {
+		return fmt.Errorf("error setting redirect uri key: %s", err)
+	}
+
+	if err := d.Set(descKey, oauthClient.Description); err != nil {
+		return fmt.Errorf("error setting description key: %s", err)
+	}
+
+	if err := d.Set(urlKey, oauthClient.URL); err != nil {
+		return fmt.Errorf("error setting url key: %s", err)
+	}
+
+	return nil
+}
@@ -9,7 +9,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 
 	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
@@ -67,11 +66,16 @@ func resourcePGPKey() *schema.Resource {
 	}
 }
 
+// resourcePGPKeyImport imports a PGP key by ID or by fingerprint.
 func resourcePGPKeyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
 	diags := resourcePGPKeyRead(ctx, d, m)
 	if diags.HasError() {
 		return nil, fmt.Errorf("error reading sourcehut PGP key: %v", diags[0].Summary)
 	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("PGP key %s not found", id)
+	}
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -79,16 +83,24 @@ func resourcePGPKeyRead(ctx context.Context, d *schema.ResourceData, m interface
 	var diags diag.Diagnostics
 	config := m.(*config)
 
-	key, err := config.client.GetPGPKey(context.Background(), 0) // TODO: implement GetPGPKeyByFingerprint
+	// The ID is the fingerprint of the key when it is imported by fingerprint
+	var key *client.PGPKey
+	id, err := strconv.Atoi(d.Id())
+	if err == nil {
+		key, err = config.client.GetPGPKey(ctx, id)
+	} else {
+		key, err = config.client.GetPGPKeyByFingerprint(ctx, d.Id())
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
-			d.SetId("")
-			return diags
-		}
 		return diag.FromErr(err)
 	}
 
-	user, err := config.client.GetCurrentUser(context.Background())
+	if key == nil {
+		d.SetId("")
+		return diags
+	}
+
+	user, err := config.client.GetCurrentUser(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
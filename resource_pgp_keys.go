@@ -0,0 +1,535 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Resource Name
+	pgpKeysName = "sourcehut_user_pgp_keys"
+
+	// Schema keys
+	expiryWarningDaysKey = "expiry_warning_days"
+	keyDetailsKey        = "key_details"
+	userIDsKey           = "user_ids"
+	algorithmKey         = "algorithm"
+	expiresKey           = "expires"
+	expiresTimestampKey  = "expires_unix"
+)
+
+func resourcePGPKeys() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePGPKeysCreate,
+		ReadContext:   resourcePGPKeysRead,
+		UpdateContext: resourcePGPKeysUpdate,
+		DeleteContext: resourcePGPKeysDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.ComputedIf(keyDetailsKey,
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChanges(keysKey, excludeFingerprintsKey)
+			}),
+		Schema: map[string]*schema.Schema{
+			keysKey: {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validatePGPKey,
+				},
+				Set: pgpKeyHash,
+				Description: `The complete set of armored PGP public keys of the account. Any
+					other key on the account is removed, unless it is excluded. Keys with the
+					same primary fingerprint are considered equal. sourcehut can't update a
+					key in place, a key whose expiry or user IDs changed is removed and added
+					again, which changes its ID. It stays the preferred key of the account if
+					it was before.`,
+			},
+			excludeFingerprintsKey: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: `Primary fingerprints of keys on the account that are left alone,
+					ignoring case, spaces and colons.`,
+			},
+			expiryWarningDaysKey: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  30,
+				Description: `The number of days before the expiry of a key from which on a
+					warning is shown. 0 to only warn about expired keys.`,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			keyDetailsKey: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The managed keys, ordered by fingerprint.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						idKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the key. It changes when an updated key replaces an outdated copy.",
+						},
+						fingerprintKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fingerprint of the primary key.",
+						},
+						userIDsKey: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The user IDs of the key (eg. 'Example <example@example.org>').",
+						},
+						algorithmKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The algorithm of the primary key (eg. 'rsa4096' or 'ed25519').",
+						},
+						expiresKey: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date on which the key expires in RFC3339 format. Empty if it doesn't expire.",
+						},
+						expiresTimestampKey: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The date on which the key expires as a unix timestamp. 0 if it doesn't expire.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// parsePGPKey parses an armored key that has to contain a single public
+// key.
+func parsePGPKey(s string) (*openpgp.Entity, error) {
+	entities, err := readArmoredKeyRing(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected a single armored PGP public key, got %d", len(entities))
+	}
+	return entities[0], nil
+}
+
+// pgpFingerprint returns the primary fingerprint of a key in upper case hex.
+func pgpFingerprint(e *openpgp.Entity) string {
+	return fmt.Sprintf("%X", e.PrimaryKey.Fingerprint)
+}
+
+// pgpAlgorithm names the algorithm of a public key the way GnuPG does.
+func pgpAlgorithm(pk *packet.PublicKey) string {
+	switch pk.PubKeyAlgo {
+	case packet.PubKeyAlgoRSA, packet.PubKeyAlgoRSAEncryptOnly, packet.PubKeyAlgoRSASignOnly:
+		bits, _ := pk.BitLength()
+		return fmt.Sprintf("rsa%d", bits)
+	case packet.PubKeyAlgoDSA:
+		bits, _ := pk.BitLength()
+		return fmt.Sprintf("dsa%d", bits)
+	case packet.PubKeyAlgoElGamal:
+		bits, _ := pk.BitLength()
+		return fmt.Sprintf("elg%d", bits)
+	}
+
+	curve, err := pk.Curve()
+	if err != nil {
+		return "unknown"
+	}
+
+	signing := pk.PubKeyAlgo == packet.PubKeyAlgoEdDSA ||
+		pk.PubKeyAlgo == packet.PubKeyAlgoEd25519 || pk.PubKeyAlgo == packet.PubKeyAlgoEd448
+	switch {
+	case curve == packet.Curve25519 && signing:
+		return "ed25519"
+	case curve == packet.Curve25519:
+		return "cv25519"
+	case curve == packet.Curve448 && signing:
+		return "ed448"
+	case curve == packet.Curve448:
+		return "cv448"
+	case strings.HasPrefix(string(curve), "P"):
+		return "nist" + strings.ToLower(string(curve))
+	default:
+		return strings.ToLower(string(curve))
+	}
+}
+
+// pgpKeyExpiry returns the expiry of a key, or nil if it doesn't expire.
+func pgpKeyExpiry(e *openpgp.Entity) *time.Time {
+	sig, _ := e.PrimarySelfSignature()
+	if sig == nil || sig.KeyLifetimeSecs == nil || *sig.KeyLifetimeSecs == 0 {
+		return nil
+	}
+	expiry := e.PrimaryKey.CreationTime.Add(time.Duration(*sig.KeyLifetimeSecs) * time.Second)
+	return &expiry
+}
+
+// pgpKeyUpdated reports whether a key differs in its expiry or user IDs
+// from the armored key registered on the account, eg. because its expiry
+// was extended.
+func pgpKeyUpdated(registered string, e *openpgp.Entity) bool {
+	r, err := parsePGPKey(registered)
+	if err != nil {
+		return false
+	}
+
+	re, ee := pgpKeyExpiry(r), pgpKeyExpiry(e)
+	if (re == nil) != (ee == nil) || (re != nil && !re.Equal(*ee)) {
+		return true
+	}
+
+	if len(r.Identities) != len(e.Identities) {
+		return true
+	}
+	for name := range e.Identities {
+		if _, ok := r.Identities[name]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validatePGPKey(v interface{}, k string) ([]string, []error) {
+	if _, err := parsePGPKey(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	return nil, nil
+}
+
+// pgpKeyHash hashes the primary fingerprint of a key, so that different
+// armored forms of a key end up as the same set element.
+func pgpKeyHash(v interface{}) int {
+	if e, err := parsePGPKey(v.(string)); err == nil {
+		return schema.HashString(pgpFingerprint(e))
+	}
+	return schema.HashString(v)
+}
+
+// pgpKeyExcluded reports whether a key on the account matches one of the
+// excluded fingerprints.
+func pgpKeyExcluded(key client.PGPKey, exclude *schema.Set) bool {
+	fingerprint := client.NormalizeFingerprint(key.Fingerprint)
+	for _, f := range exclude.List() {
+		if client.NormalizeFingerprint(f.(string)) == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+func resourcePGPKeysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	user, err := config.client.GetCurrentUser(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(user.CanonicalName)
+
+	return resourcePGPKeysApply(ctx, d, m)
+}
+
+func resourcePGPKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	keys, err := config.client.GetPGPKeys(ctx, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setPGPKeys(d, keys)
+}
+
+func resourcePGPKeysUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePGPKeysApply(ctx, d, m)
+}
+
+func resourcePGPKeysDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	// Only the managed keys are removed, the account keeps any excluded key
+	var errs []error
+	for _, detail := range d.Get(keyDetailsKey).([]interface{}) {
+		detail := detail.(map[string]interface{})
+		if err := config.client.DeletePGPKey(ctx, detail[idKey].(int)); err != nil {
+			errs = append(errs, fmt.Errorf("error removing PGP key %s: %w", detail[fingerprintKey], err))
+		}
+	}
+
+	return diag.FromErr(errors.Join(errs...))
+}
+
+// resourcePGPKeysApply brings the PGP keys of the account in line with the
+// configuration. The resulting keys are always read back, so that a partial
+// failure leaves the state matching what was actually applied.
+func resourcePGPKeysApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	var want []string
+	for _, key := range d.Get(keysKey).(*schema.Set).List() {
+		want = append(want, key.(string))
+	}
+
+	replaced, applyErr := reconcilePGPKeys(ctx, config.client, want, d.Get(excludeFingerprintsKey).(*schema.Set))
+
+	keys, err := config.client.GetPGPKeys(ctx, "")
+	if err != nil {
+		return diag.FromErr(errors.Join(applyErr, err))
+	}
+
+	diags := setPGPKeys(d, keys)
+	for _, r := range replaced {
+		detail := "sourcehut can't update a key in place, so the outdated copy was " +
+			"removed and the key added again. It has a new ID now."
+		if r.preferred {
+			detail += " It was made the preferred key of the account again."
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("PGP key %s was replaced", r.fingerprint),
+			Detail:   detail,
+		})
+	}
+	if applyErr != nil {
+		diags = append(diags, diag.FromErr(applyErr)...)
+	}
+
+	return diags
+}
+
+// pgpKeyReplacement is an outdated key that was replaced by its updated
+// copy. preferred is set if the key was the preferred key of the account.
+type pgpKeyReplacement struct {
+	fingerprint string
+	preferred   bool
+}
+
+// reconcilePGPKeys adds every key in want that is missing or outdated on the
+// account and removes any other key that isn't excluded. The outdated keys
+// that were replaced are returned, as they get a new ID.
+func reconcilePGPKeys(ctx context.Context, c *client.Client, want []string, exclude *schema.Set) ([]pgpKeyReplacement, error) {
+	keys, err := c.GetPGPKeys(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	have := make(map[string]client.PGPKey, len(keys))
+	for _, key := range keys {
+		have[client.NormalizeFingerprint(key.Fingerprint)] = key
+	}
+
+	// The preferred key is only looked up once a key has to be replaced
+	var preferred *string
+
+	wanted := make(map[string]bool, len(want))
+	var replaced []pgpKeyReplacement
+	var errs []error
+	for _, key := range want {
+		e, err := parsePGPKey(key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fingerprint := pgpFingerprint(e)
+		wanted[fingerprint] = true
+
+		existing, ok := have[fingerprint]
+		if !ok {
+			if _, err := c.CreatePGPKey(ctx, key); err != nil {
+				errs = append(errs, fmt.Errorf("error adding PGP key %s: %w", fingerprint, err))
+			}
+			continue
+		}
+		if !pgpKeyUpdated(existing.Key, e) {
+			continue
+		}
+
+		if preferred == nil {
+			user, err := c.GetCurrentUser(ctx)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			var p string
+			if user.PGPKey != nil {
+				p = client.NormalizeFingerprint(user.PGPKey.Fingerprint)
+			}
+			preferred = &p
+		}
+
+		if err := replacePGPKey(ctx, c, existing, key, *preferred == fingerprint); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		replaced = append(replaced, pgpKeyReplacement{fingerprint, *preferred == fingerprint})
+	}
+
+	for _, key := range keys {
+		if wanted[client.NormalizeFingerprint(key.Fingerprint)] || pgpKeyExcluded(key, exclude) {
+			continue
+		}
+		if err := c.DeletePGPKey(ctx, key.ID); err != nil {
+			errs = append(errs, fmt.Errorf("error removing PGP key %s: %w", key.Fingerprint, err))
+		}
+	}
+
+	return replaced, errors.Join(errs...)
+}
+
+// replacePGPKey replaces the outdated copy of a key on the account with its
+// updated form. A key can't be added twice, so the outdated copy has to go
+// first. It is added back if the updated key is refused, and a preferred key
+// is made the preferred one again, as removing it clears the preference.
+func replacePGPKey(ctx context.Context, c *client.Client, existing client.PGPKey, key string, preferred bool) error {
+	fingerprint := client.NormalizeFingerprint(existing.Fingerprint)
+
+	if err := c.DeletePGPKey(ctx, existing.ID); err != nil {
+		return fmt.Errorf("error removing outdated PGP key %s: %w", fingerprint, err)
+	}
+
+	var errs []error
+	created, err := c.CreatePGPKey(ctx, key)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error adding PGP key %s: %w", fingerprint, err))
+		created, err = c.CreatePGPKey(ctx, existing.Key)
+		if err != nil {
+			return errors.Join(append(errs,
+				fmt.Errorf("error restoring outdated PGP key %s: %w", fingerprint, err))...)
+		}
+	}
+
+	if preferred {
+		if _, err := c.UpdateUser(ctx, client.UserInput{PGPKey: &created.ID}); err != nil {
+			errs = append(errs, fmt.Errorf("error restoring PGP key %s as preferred key: %w", fingerprint, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setPGPKeys stores every key of the account that isn't excluded, so that
+// unmanaged keys show up as drift. Keys that are already in the state keep
+// their configured form unless sourcehut has an outdated copy. A warning is
+// returned for every key that expires within the warning window.
+func setPGPKeys(d *schema.ResourceData, keys []client.PGPKey) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	current := make(map[string]string)
+	for _, key := range d.Get(keysKey).(*schema.Set).List() {
+		if e, err := parsePGPKey(key.(string)); err == nil {
+			current[pgpFingerprint(e)] = key.(string)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return client.NormalizeFingerprint(keys[i].Fingerprint) < client.NormalizeFingerprint(keys[j].Fingerprint)
+	})
+
+	exclude := d.Get(excludeFingerprintsKey).(*schema.Set)
+	window := time.Duration(d.Get(expiryWarningDaysKey).(int)) * 24 * time.Hour
+	now := time.Now()
+
+	list := make([]interface{}, 0, len(keys))
+	details := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		fingerprint := client.NormalizeFingerprint(key.Fingerprint)
+		configured, managed := current[fingerprint]
+		if !managed && pgpKeyExcluded(key, exclude) {
+			continue
+		}
+
+		// An outdated copy of a configured key shows up as drift
+		if e, err := parsePGPKey(configured); managed && err == nil && !pgpKeyUpdated(key.Key, e) {
+			list = append(list, configured)
+		} else {
+			list = append(list, key.Key)
+		}
+
+		detail := map[string]interface{}{
+			idKey:               key.ID,
+			fingerprintKey:      fingerprint,
+			userIDsKey:          []string{},
+			algorithmKey:        "",
+			expiresKey:          "",
+			expiresTimestampKey: 0,
+		}
+		details = append(details, detail)
+
+		e, err := parsePGPKey(key.Key)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unable to parse PGP key %s", fingerprint),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+
+		userIDs := make([]string, 0, len(e.Identities))
+		for name := range e.Identities {
+			userIDs = append(userIDs, name)
+		}
+		sort.Strings(userIDs)
+		detail[userIDsKey] = userIDs
+		detail[algorithmKey] = pgpAlgorithm(e.PrimaryKey)
+
+		expiry := pgpKeyExpiry(e)
+		if expiry == nil {
+			continue
+		}
+		detail[expiresKey] = expiry.Format(time.RFC3339)
+		detail[expiresTimestampKey] = expiry.Unix()
+
+		switch {
+		case expiry.Before(now):
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("PGP key %s has expired", fingerprint),
+				Detail: fmt.Sprintf("The key expired on %s. sourcehut can't encrypt emails "+
+					"to an expired key.", expiry.Format(time.RFC3339)),
+			})
+		case expiry.Before(now.Add(window)):
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("PGP key %s expires soon", fingerprint),
+				Detail: fmt.Sprintf("The key expires on %s. Extend its expiry and update "+
+					"it on sourcehut.", expiry.Format(time.RFC3339)),
+			})
+		}
+	}
+
+	if err := d.Set(keysKey, schema.NewSet(pgpKeyHash, list)); err != nil {
+		return append(diags, diag.FromErr(fmt.Errorf("error setting keys key: %s", err))...)
+	}
+
+	if err := d.Set(keyDetailsKey, details); err != nil {
+		return append(diags, diag.FromErr(fmt.Errorf("error setting key details key: %s", err))...)
+	}
+
+	return diags
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testPGPKey(t *testing.T, name string, lifetime time.Duration) (string, string) {
+	e, err := openpgp.NewEntity(name, "", strings.ToLower(name)+"@example.org", &packet.Config{
+		Algorithm:       packet.PubKeyAlgoEdDSA,
+		KeyLifetimeSecs: uint32(lifetime.Seconds()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testArmoredPublicKey(t, e), pgpFingerprint(e)
+}
+
+func TestPGPKeyUpdated(t *testing.T) {
+	armored, _ := testPGPKey(t, "Example", 0)
+
+	e, err := parsePGPKey(armored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pgpKeyUpdated(armored, e) {
+		t.Error("expected the same key not to be updated")
+	}
+
+	// Extend the expiry of the parsed copy
+	sig, _ := e.PrimarySelfSignature()
+	lifetime := uint32(3600)
+	sig.KeyLifetimeSecs = &lifetime
+	if !pgpKeyUpdated(armored, e) {
+		t.Error("expected a key with a new expiry to be updated")
+	}
+}
+
+func TestSetPGPKeys(t *testing.T) {
+	expiring, expiringFP := testPGPKey(t, "Expiring", 10*24*time.Hour)
+	unmanaged, unmanagedFP := testPGPKey(t, "Unmanaged", 0)
+	excluded, excludedFP := testPGPKey(t, "Excluded", 0)
+
+	configured := "\n" + expiring
+	d := schema.TestResourceDataRaw(t, resourcePGPKeys().Schema, map[string]interface{}{
+		keysKey:                []interface{}{configured},
+		excludeFingerprintsKey: []interface{}{strings.ToLower(excludedFP)},
+	})
+
+	keys := []client.PGPKey{
+		{ID: 1, Key: expiring, Fingerprint: expiringFP},
+		{ID: 2, Key: unmanaged, Fingerprint: unmanagedFP},
+		{ID: 3, Key: excluded, Fingerprint: excludedFP},
+	}
+
+	diags := setPGPKeys(d, keys)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning ||
+		!strings.Contains(diags[0].Summary, "expires soon") {
+		t.Errorf("expected a single expiry warning, got %+v", diags)
+	}
+
+	got := d.Get(keysKey).(*schema.Set)
+	if got.Len() != 2 || !got.Contains(configured) || !got.Contains(unmanaged) {
+		t.Errorf("expected the configured and the unmanaged key, got %d keys", got.Len())
+	}
+
+	details := d.Get(keyDetailsKey).([]interface{})
+	if len(details) != 2 {
+		t.Fatalf("expected details of 2 keys, got %d", len(details))
+	}
+	for _, detail := range details {
+		detail := detail.(map[string]interface{})
+		if detail[algorithmKey] != "ed25519" {
+			t.Errorf("expected algorithm ed25519, got %v", detail[algorithmKey])
+		}
+		switch detail[fingerprintKey] {
+		case expiringFP:
+			if detail[expiresKey] == "" || detail[idKey] != 1 {
+				t.Errorf("unexpected details of the expiring key %v", detail)
+			}
+			if ids := detail[userIDsKey].([]interface{}); len(ids) != 1 ||
+				ids[0] != "Expiring <expiring@example.org>" {
+				t.Errorf("unexpected user ids %v", ids)
+			}
+		case unmanagedFP:
+			if detail[expiresKey] != "" || detail[idKey] != 2 {
+				t.Errorf("unexpected details of the unmanaged key %v", detail)
+			}
+		default:
+			t.Errorf("unexpected key %v", detail[fingerprintKey])
+		}
+	}
+
+	// Without a warning window only expired keys are reported
+	if err := d.Set(expiryWarningDaysKey, 0); err != nil {
+		t.Fatal(err)
+	}
+	if diags := setPGPKeys(d, keys); len(diags) != 0 {
+		t.Errorf("expected no warnings, got %+v", diags)
+	}
+}
+
+func TestReconcilePGPKeysReplace(t *testing.T) {
+	e, err := openpgp.NewEntity("Example", "", "example@example.org", &packet.Config{
+		Algorithm: packet.PubKeyAlgoEdDSA,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outdated, fingerprint := testArmoredPublicKey(t, e), pgpFingerprint(e)
+	if err := e.AddUserId("Example", "", "example@example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	updated := testArmoredPublicKey(t, e)
+
+	tests := []struct {
+		name       string
+		refuse     bool
+		wantErr    bool
+		wantKey    string
+		wantLength int
+	}{
+		{name: "updated", wantKey: updated, wantLength: 1},
+		{name: "refused", refuse: true, wantErr: true, wantKey: outdated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The account starts with the outdated key as preferred key
+			account := map[int]string{1: outdated}
+			preferred := 1
+			nextID := 2
+
+			config := testConfig(t, func(host, query string, vars map[string]interface{}) interface{} {
+				switch {
+				case strings.Contains(query, "query GetPGPKeys"):
+					results := []interface{}{}
+					for id, key := range account {
+						results = append(results, map[string]interface{}{
+							"id": id, "created": "2026-01-02T12:00:00Z", "key": key, "fingerprint": fingerprint,
+						})
+					}
+					return map[string]interface{}{
+						"user": map[string]interface{}{
+							"pgpKeys": map[string]interface{}{"results": results, "cursor": nil},
+						},
+					}
+				case strings.Contains(query, "query GetCurrentUser"):
+					var key interface{}
+					if preferred != 0 {
+						key = map[string]interface{}{"id": preferred, "fingerprint": fingerprint}
+					}
+					return map[string]interface{}{"me": map[string]interface{}{"pgpKey": key}}
+				case strings.Contains(query, "mutation DeletePGPKey"):
+					id := int(vars["id"].(float64))
+					delete(account, id)
+					// Removing the preferred key clears the preference
+					if id == preferred {
+						preferred = 0
+					}
+					return map[string]interface{}{"deletePGPKey": map[string]interface{}{"id": id}}
+				case strings.Contains(query, "mutation CreatePGPKey"):
+					if tt.refuse && vars["key"] == updated {
+						return errors.New("key refused")
+					}
+					id := nextID
+					nextID++
+					account[id] = vars["key"].(string)
+					return map[string]interface{}{
+						"createPGPKey": map[string]interface{}{"id": id, "fingerprint": fingerprint},
+					}
+				case strings.Contains(query, "mutation UpdateUser"):
+					input := vars["input"].(map[string]interface{})
+					preferred = int(input["pgpKey"].(float64))
+					return map[string]interface{}{
+						"updateUser": map[string]interface{}{
+							"pgpKey": map[string]interface{}{"id": preferred, "fingerprint": fingerprint},
+						},
+					}
+				}
+				t.Fatalf("Unexpected query %s", query)
+				return nil
+			})
+
+			replaced, err := reconcilePGPKeys(context.Background(), config.client, []string{updated}, schema.NewSet(schema.HashString, nil))
+			if tt.wantErr != (err != nil) {
+				t.Errorf("Expected error %t, got %v", tt.wantErr, err)
+			}
+			if len(replaced) != tt.wantLength {
+				t.Errorf("Expected %d replaced keys, got %v", tt.wantLength, replaced)
+			}
+			for _, r := range replaced {
+				if r.fingerprint != fingerprint || !r.preferred {
+					t.Errorf("Expected preferred key %s to be replaced, got %+v", fingerprint, r)
+				}
+			}
+
+			if len(account) != 1 {
+				t.Fatalf("Expected a single key on the account, got %d", len(account))
+			}
+			if key := account[preferred]; key != tt.wantKey {
+				t.Errorf("Expected the key to stay the preferred key, got preferred key %d of %v", preferred, account)
+			}
+		})
+	}
+}
@@ -7,11 +7,14 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"git.sr.ht/~emersion/gqlclient"
@@ -27,11 +30,30 @@ const (
 	descKey    = "description"
 	visiKey    = "visibility"
 	subjectKey = "subject"
+	ownerKey   = "owner"
+
+	webURLKey        = "web_url"
+	httpsCloneURLKey = "https_clone_url"
+	sshCloneURLKey   = "ssh_clone_url"
+
+	allowPublishKey = "allow_publish"
 )
 
 // repoSchema returns a schema that is used by both the repo resource and the
 // repo datasource.
 func repoSchema() map[string]*schema.Schema {
+	s := baseRepoSchema()
+	s[subjectKey] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The message subject.",
+	}
+	return s
+}
+
+// baseRepoSchema returns the arguments and attributes shared by the git and
+// mercurial repository schemas.
+func baseRepoSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		nameKey: {
 			Type:        schema.TypeString,
@@ -62,15 +84,33 @@ func repoSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "The date on which the repo was created as a unix timestamp.",
 		},
-		subjectKey: {
+		ownerKey: {
 			Type:        schema.TypeString,
 			Computed:    true,
-			Description: "The message subject.",
+			Description: "The canonical name of the user that owns the repository (eg. '~example').",
+		},
+		webURLKey: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the repository in the web interface.",
+		},
+		httpsCloneURLKey: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL to clone the repository over HTTPS.",
+		},
+		sshCloneURLKey: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL to clone the repository over SSH.",
 		},
 	}
 }
 
 func resourceRepo() *schema.Resource {
+	s := repoSchema()
+	addRepoGuardSchema(s)
+
 	return &schema.Resource{
 		Create: resourceRepoCreate,
 		Read:   resourceRepoRead,
@@ -80,10 +120,89 @@ func resourceRepo() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceRepoImport,
 		},
-		Schema: repoSchema(),
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf(webURLKey, repoNameChanged),
+			customdiff.ComputedIf(httpsCloneURLKey, repoNameChanged),
+			customdiff.ComputedIf(sshCloneURLKey, repoNameChanged),
+			repoDeletionProtectionDefault,
+			repoVisibilityGuard,
+		),
+		Schema: s,
+	}
+}
+
+// addRepoGuardSchema adds the deletion_protection and allow_publish
+// arguments shared by the repository resources to a schema.
+func addRepoGuardSchema(s map[string]*schema.Schema) {
+	s[deletionProtectionKey] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+		Description: `Whether the repository is protected from deletion. It has to be
+			disabled and applied before the repository can be destroyed. Defaults to
+			the deletion_protection setting of the provider.`,
+	}
+	s[allowPublishKey] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: `Whether a private repository may be made public or unlisted. Changing
+			the visibility of a private repository is refused unless this is set.`,
 	}
 }
 
+// repoDeletionCheck refuses to delete a repository that has deletion
+// protection enabled.
+func repoDeletionCheck(d *schema.ResourceData) error {
+	if d.Get(deletionProtectionKey).(bool) {
+		return fmt.Errorf("repository %s has deletion protection enabled, set %s = false "+
+			"and apply before destroying it", d.Get(nameKey).(string), deletionProtectionKey)
+	}
+	return nil
+}
+
+// repoDeletionProtectionDefault plans the provider default for
+// deletion_protection if the repository doesn't configure it.
+func repoDeletionProtectionDefault(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Without the raw config an explicit false can't be told apart from an
+	// unset value
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.GetAttr(deletionProtectionKey).IsNull() {
+		return nil
+	}
+
+	def := meta.(*config).deletionProtection
+	if d.Id() != "" && d.Get(deletionProtectionKey).(bool) == def {
+		return nil
+	}
+	return d.SetNew(deletionProtectionKey, def)
+}
+
+// repoVisibilityGuard refuses to change the visibility of a private
+// repository unless allow_publish is set.
+func repoVisibilityGuard(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange(visiKey) {
+		return nil
+	}
+
+	oldVisi, newVisi := d.GetChange(visiKey)
+	if strings.ToUpper(oldVisi.(string)) != "PRIVATE" || strings.ToUpper(newVisi.(string)) == "PRIVATE" {
+		return nil
+	}
+
+	if !d.Get(allowPublishKey).(bool) {
+		return fmt.Errorf("refusing to change the visibility of private repository %s to %s, "+
+			"set %s = true to allow it", d.Get(nameKey).(string), strings.ToUpper(newVisi.(string)), allowPublishKey)
+	}
+	return nil
+}
+
+// repoNameChanged reports whether a rename is planned, in which case the
+// URLs derived from the name are not known until after apply.
+func repoNameChanged(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+	return d.HasChange(nameKey)
+}
+
 func resourceRepoCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*config)
 	input := client.RepositoryInput{
@@ -97,7 +216,7 @@ func resourceRepoCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	return setRepo(d, repo)
+	return setRepo(d, config.gitURL, repo)
 }
 
 func resourceRepoRead(d *schema.ResourceData, meta interface{}) error {
@@ -122,17 +241,28 @@ func repoRead(d *schema.ResourceData, meta interface{}, importing bool) error {
 		return err
 	}
 
-	return setRepo(d, repo)
+	return setRepo(d, config.gitURL, repo)
 }
 
 func resourceRepoDelete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*config)
+
+	if err := repoDeletionCheck(d); err != nil {
+		return err
+	}
+
 	id, _ := strconv.Atoi(d.Id())
 	return config.client.DeleteRepository(context.Background(), id)
 }
 
 func resourceRepoUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*config)
+
+	// deletion_protection and allow_publish only live in the state
+	if !d.HasChanges(nameKey, descKey, visiKey) {
+		return nil
+	}
+
 	id, _ := strconv.Atoi(d.Id())
 	oldName, newName := d.GetChange(nameKey)
 
@@ -145,8 +275,12 @@ func resourceRepoUpdate(d *schema.ResourceData, meta interface{}) error {
 		input.Name = newName.(string)
 	}
 
-	_, err := config.client.UpdateRepository(context.Background(), id, input)
-	return err
+	repo, err := config.client.UpdateRepository(context.Background(), id, input)
+	if err != nil {
+		return err
+	}
+
+	return setRepo(d, config.gitURL, repo)
 }
 
 func resourceRepoImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -154,10 +288,34 @@ func resourceRepoImport(d *schema.ResourceData, meta interface{}) ([]*schema.Res
 	if err != nil {
 		return nil, err
 	}
+	err = d.Set(deletionProtectionKey, meta.(*config).deletionProtection)
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set(allowPublishKey, false)
+	if err != nil {
+		return nil, err
+	}
 	return []*schema.ResourceData{d}, nil
 }
 
-func setRepo(d *schema.ResourceData, repo *client.Repository) error {
+// repoURLs derives the web, HTTPS clone and SSH clone URLs of a repository
+// from the git.sr.ht API endpoint, eg. "https://git.sr.ht/api" yields
+// "https://git.sr.ht/~example/repo" and "git@git.sr.ht:~example/repo".
+func repoURLs(gitURL, owner, name string) (web, https, ssh string, err error) {
+	u, err := url.Parse(gitURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid git URL %q: %w", gitURL, err)
+	}
+	if u.Host == "" {
+		return "", "", "", fmt.Errorf("invalid git URL %q: missing host", gitURL)
+	}
+
+	web = fmt.Sprintf("%s://%s/%s/%s", u.Scheme, u.Host, owner, name)
+	return web, web, fmt.Sprintf("git@%s:%s/%s", u.Hostname(), owner, name), nil
+}
+
+func setRepo(d *schema.ResourceData, gitURL string, repo *client.Repository) error {
 	d.SetId(strconv.Itoa(repo.ID))
 	err := d.Set(createdKey, repo.Created.Format(time.RFC3339))
 	if err != nil {
@@ -179,5 +337,26 @@ func setRepo(d *schema.ResourceData, repo *client.Repository) error {
 	if err != nil {
 		return err
 	}
+	err = d.Set(ownerKey, repo.Owner.CanonicalName)
+	if err != nil {
+		return err
+	}
+
+	web, https, ssh, err := repoURLs(gitURL, repo.Owner.CanonicalName, repo.Name)
+	if err != nil {
+		return err
+	}
+	err = d.Set(webURLKey, web)
+	if err != nil {
+		return err
+	}
+	err = d.Set(httpsCloneURLKey, https)
+	if err != nil {
+		return err
+	}
+	err = d.Set(sshCloneURLKey, ssh)
+	if err != nil {
+		return err
+	}
 	return d.Set(nameKey, repo.Name)
 }
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Resource Name
+	repoACLName = "sourcehut_repository_acl"
+
+	// Schema keys
+	repoIDKey = "repository_id"
+	entityKey = "entity"
+	modeKey   = "mode"
+)
+
+// entityRegexp matches the canonical name of a user, eg. '~example'.
+var entityRegexp = regexp.MustCompile(`^~[^~/\s]+$`)
+
+func resourceRepoACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepoACLCreate,
+		ReadContext:   resourceRepoACLRead,
+		UpdateContext: resourceRepoACLUpdate,
+		DeleteContext: resourceRepoACLDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			repoIDKey: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the repository.",
+			},
+			entityKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The canonical name of the user that is granted access " +
+					"(eg. '~example').",
+				ValidateFunc: validation.StringMatch(entityRegexp,
+					"must be the canonical name of a user (eg. '~example')"),
+			},
+			modeKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  `The access mode granted to the entity ("RO" or "RW").`,
+				ValidateFunc: validation.StringInSlice([]string{"RO", "RW"}, true),
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+			},
+			createdKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date on which access was granted in RFC3339 format.",
+			},
+			createdTimestampKey: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The date on which access was granted as a unix timestamp.",
+			},
+		},
+	}
+}
+
+// parseRepoACLID splits a resource ID in the form "<repository id>/~user".
+func parseRepoACLID(id string) (int, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || !entityRegexp.MatchString(parts[1]) {
+		return 0, "", fmt.Errorf("invalid resource id %q, expected <repository id>/~user", id)
+	}
+
+	repoID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid repository id in resource id %q", id)
+	}
+
+	return repoID, parts[1], nil
+}
+
+func resourceRepoACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	repoID := d.Get(repoIDKey).(int)
+
+	acl, err := config.client.UpdateACL(ctx, repoID,
+		strings.ToUpper(d.Get(modeKey).(string)), d.Get(entityKey).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", repoID, acl.Entity.CanonicalName))
+
+	if err := setRepoACL(d, repoID, acl); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceRepoACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	repoID, entity, err := parseRepoACLID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	acl, err := config.client.GetACL(ctx, repoID, entity)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if acl == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := setRepoACL(d, repoID, acl); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceRepoACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	repoID := d.Get(repoIDKey).(int)
+
+	acl, err := config.client.UpdateACL(ctx, repoID,
+		strings.ToUpper(d.Get(modeKey).(string)), d.Get(entityKey).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setRepoACL(d, repoID, acl); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceRepoACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	repoID, entity, err := parseRepoACLID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ACL ID is not part of the resource ID, look it up first
+	acl, err := config.client.GetACL(ctx, repoID, entity)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if acl == nil {
+		return diags
+	}
+
+	if err := config.client.DeleteACL(ctx, acl.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func setRepoACL(d *schema.ResourceData, repoID int, acl *client.ACL) error {
+	if err := d.Set(repoIDKey, repoID); err != nil {
+		return fmt.Errorf("error setting repository id key: %s", err)
+	}
+
+	if err := d.Set(entityKey, acl.Entity.CanonicalName); err != nil {
+		return fmt.Errorf("error setting entity key: %s", err)
+	}
+
+	if err := d.Set(modeKey, acl.Mode); err != nil {
+		return fmt.Errorf("error setting mode key: %s", err)
+	}
+
+	if err := d.Set(createdKey, acl.Created.Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("error setting created key: %s", err)
+	}
+
+	if err := d.Set(createdTimestampKey, acl.Created.Unix()); err != nil {
+		return fmt.Errorf("error setting created timestamp key: %s", err)
+	}
+
+	return nil
+}
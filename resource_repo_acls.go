@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Resource Name
+	repoACLsName = "sourcehut_repository_acls"
+
+	// Schema keys
+	aclsKey   = "acls"
+	aclIDsKey = "acl_ids"
+)
+
+func resourceRepoACLs() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepoACLsCreate,
+		ReadContext:   resourceRepoACLsRead,
+		UpdateContext: resourceRepoACLsUpdate,
+		DeleteContext: resourceRepoACLsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.ComputedIf(aclIDsKey,
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange(aclsKey)
+			}),
+		Schema: map[string]*schema.Schema{
+			repoIDKey: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the repository.",
+			},
+			aclsKey: {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: `The complete access list of the repository, mapping the canonical
+					name of a user (eg. '~example') to an access mode ("RO" or "RW", case
+					insensitive). Any other entry on the repository is removed.`,
+				ValidateDiagFunc: validation.AllDiag(
+					validation.MapKeyMatch(entityRegexp,
+						"keys must be the canonical name of a user (eg. '~example')"),
+					validation.MapValueMatch(regexp.MustCompile(`^(?i)(RO|RW)$`),
+						`values must be "RO" or "RW"`),
+				),
+				DiffSuppressFunc: aclModeDiffSuppress,
+			},
+			aclIDsKey: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the access list entries, keyed by the canonical name of the user.",
+			},
+		},
+	}
+}
+
+// aclModeDiffSuppress suppresses the diff of access modes that only differ
+// in case, like sourcehut_repository_acl does.
+func aclModeDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+func resourceRepoACLsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(strconv.Itoa(d.Get(repoIDKey).(int)))
+	return resourceRepoACLsApply(ctx, d, m)
+}
+
+func resourceRepoACLsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	repoID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid resource id: %s", d.Id()))
+	}
+
+	acls, err := config.client.GetACLs(ctx, repoID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if acls == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := setRepoACLs(d, repoID, acls); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceRepoACLsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceRepoACLsApply(ctx, d, m)
+}
+
+func resourceRepoACLsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	repoID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid resource id: %s", d.Id()))
+	}
+
+	if err := reconcileRepoACLs(ctx, config.client, repoID, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceRepoACLsApply brings the access list of the repository in line
+// with the configuration. The resulting list is always read back, so that a
+// partial failure leaves the state matching what was actually applied.
+func resourceRepoACLsApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	repoID := d.Get(repoIDKey).(int)
+
+	want := make(map[string]string)
+	for entity, mode := range d.Get(aclsKey).(map[string]interface{}) {
+		want[entity] = strings.ToUpper(mode.(string))
+	}
+
+	applyErr := reconcileRepoACLs(ctx, config.client, repoID, want)
+
+	acls, err := config.client.GetACLs(ctx, repoID)
+	if err != nil {
+		return diag.FromErr(errors.Join(applyErr, err))
+	}
+
+	if err := setRepoACLs(d, repoID, acls); err != nil {
+		return diag.FromErr(errors.Join(applyErr, err))
+	}
+
+	return diag.FromErr(applyErr)
+}
+
+// reconcileRepoACLs grants every entity in want its access mode and removes
+// any other entry from the access list of the repository. Grants are applied
+// before removals, so nobody loses access on the way.
+func reconcileRepoACLs(ctx context.Context, c *client.Client, repoID int, want map[string]string) error {
+	acls, err := c.GetACLs(ctx, repoID)
+	if err != nil {
+		return err
+	}
+
+	have := make(map[string]client.ACL, len(acls))
+	for _, acl := range acls {
+		have[acl.Entity.CanonicalName] = acl
+	}
+
+	entities := make([]string, 0, len(want))
+	for entity := range want {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+
+	var errs []error
+	for _, entity := range entities {
+		if acl, ok := have[entity]; ok && acl.Mode == want[entity] {
+			continue
+		}
+		if _, err := c.UpdateACL(ctx, repoID, want[entity], entity); err != nil {
+			errs = append(errs, fmt.Errorf("error granting %s access to %s: %w", want[entity], entity, err))
+		}
+	}
+
+	for _, acl := range acls {
+		if _, ok := want[acl.Entity.CanonicalName]; ok {
+			continue
+		}
+		if err := c.DeleteACL(ctx, acl.ID); err != nil {
+			errs = append(errs, fmt.Errorf("error revoking access of %s: %w", acl.Entity.CanonicalName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setRepoACLs stores the access list of the repository. Configured access
+// modes keep their case as long as they match the mode on sourcehut.
+func setRepoACLs(d *schema.ResourceData, repoID int, acls []client.ACL) error {
+	current := d.Get(aclsKey).(map[string]interface{})
+	modes := make(map[string]interface{}, len(acls))
+	ids := make(map[string]interface{}, len(acls))
+	for _, acl := range acls {
+		mode := acl.Mode
+		if configured, ok := current[acl.Entity.CanonicalName].(string); ok && strings.EqualFold(configured, mode) {
+			mode = configured
+		}
+		modes[acl.Entity.CanonicalName] = mode
+		ids[acl.Entity.CanonicalName] = acl.ID
+	}
+
+	if err := d.Set(repoIDKey, repoID); err != nil {
+		return fmt.Errorf("error setting repository id key: %s", err)
+	}
+
+	if err := d.Set(aclsKey, modes); err != nil {
+		return fmt.Errorf("error setting acls key: %s", err)
+	}
+
+	if err := d.Set(aclIDsKey, ids); err != nil {
+		return fmt.Errorf("error setting acl ids key: %s", err)
+	}
+
+	return nil
+}
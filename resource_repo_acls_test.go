@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRepoACLsValidateMode(t *testing.T) {
+	validate := resourceRepoACLs().Schema[aclsKey].ValidateDiagFunc
+
+	for mode, wantErr := range map[string]bool{"RO": false, "rw": false, "Rw": false, "RX": true} {
+		diags := validate(map[string]interface{}{"~example": mode}, nil)
+		if diags.HasError() != wantErr {
+			t.Errorf("mode %q: expected error %t, got %v", mode, wantErr, diags)
+		}
+	}
+}
+
+func TestSetRepoACLs(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceRepoACLs().Schema, map[string]interface{}{
+		repoIDKey: 1,
+		aclsKey: map[string]interface{}{
+			"~alice": "rw",
+			"~bob":   "ro",
+		},
+	})
+
+	acls := []client.ACL{
+		{ID: 1, Mode: "RW", Entity: client.User{CanonicalName: "~alice"}},
+		{ID: 2, Mode: "RW", Entity: client.User{CanonicalName: "~bob"}},
+		{ID: 3, Mode: "RO", Entity: client.User{CanonicalName: "~carol"}},
+	}
+
+	if err := setRepoACLs(d, 1, acls); err != nil {
+		t.Fatal(err)
+	}
+
+	// Matching modes keep their configured case, others show up as drift
+	want := map[string]interface{}{"~alice": "rw", "~bob": "RW", "~carol": "RO"}
+	if got := d.Get(aclsKey); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected acls %v, got %v", want, got)
+	}
+
+	if !aclModeDiffSuppress(aclsKey+".~alice", "RW", "rw", d) {
+		t.Error("expected a case-only change of the mode to be suppressed")
+	}
+}
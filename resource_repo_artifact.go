@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Resource Name
+	repoArtifactName = "sourcehut_repository_artifact"
+
+	// Schema keys
+	revspecKey    = "revspec"
+	sourceKey     = "source"
+	filenameKey   = "filename"
+	checksumKey   = "checksum"
+	sizeKey       = "size"
+	sourceHashKey = "source_hash"
+)
+
+func resourceRepoArtifact() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepoArtifactCreate,
+		ReadContext:   resourceRepoArtifactRead,
+		DeleteContext: resourceRepoArtifactDelete,
+
+		CustomizeDiff: resourceRepoArtifactDiff,
+		Schema: map[string]*schema.Schema{
+			repoIDKey: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the repository.",
+			},
+			revspecKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The tag to attach the artifact to (eg. 'v1.0.0' or 'refs/tags/v1.0.0').",
+			},
+			sourceKey: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The path of the file to upload.",
+			},
+			sourceHashKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: `An arbitrary hash of the source file (eg.
+					'filesha256("dist/release.tar.gz")'), a change replaces the artifact.
+					It is required to detect changes of files that are only created
+					during apply.`,
+			},
+			filenameKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the artifact. Defaults to the base name of the source file.",
+			},
+			checksumKey: {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: `The checksum of the artifact as reported by sourcehut. A change
+					of the contents of an existing source file replaces the artifact.`,
+			},
+			sizeKey: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the artifact in bytes.",
+			},
+			urlKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL to download the artifact.",
+			},
+			createdKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date on which the artifact was uploaded in RFC3339 format.",
+			},
+			createdTimestampKey: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The date on which the artifact was uploaded as a unix timestamp.",
+			},
+		},
+	}
+}
+
+// resourceRepoArtifactDiff hashes the source file of an existing artifact
+// and plans a replacement if its contents no longer match the uploaded
+// checksum. Missing source files are skipped, as they may only be created
+// during apply.
+func resourceRepoArtifactDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown(sourceKey) {
+		return nil
+	}
+
+	uploaded, ok := artifactSHA256(d.Get(checksumKey).(string))
+	if !ok {
+		return nil
+	}
+
+	sum, err := fileSHA256(d.Get(sourceKey).(string))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if sum == uploaded {
+		return nil
+	}
+
+	if err := d.SetNewComputed(checksumKey); err != nil {
+		return err
+	}
+	return d.ForceNew(checksumKey)
+}
+
+// artifactSHA256 returns the hex encoded SHA-256 hash of an artifact checksum,
+// which is either prefixed with its algorithm (eg. 'sha256:...') or a bare
+// hash. It reports false for checksums of any other format.
+func artifactSHA256(checksum string) (string, bool) {
+	sum := checksum
+	if algorithm, hash, ok := strings.Cut(checksum, ":"); ok {
+		if !strings.EqualFold(algorithm, "sha256") {
+			return "", false
+		}
+		sum = hash
+	}
+
+	if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+		return "", false
+	}
+	return strings.ToLower(sum), true
+}
+
+// fileSHA256 returns the hex encoded SHA-256 hash of a file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("error hashing %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// artifactRef returns the full name of the tag a revspec refers to.
+func artifactRef(revspec string) string {
+	if strings.HasPrefix(revspec, "refs/") {
+		return revspec
+	}
+	return "refs/tags/" + revspec
+}
+
+func resourceRepoArtifactCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	source := d.Get(sourceKey).(string)
+
+	filename := d.Get(filenameKey).(string)
+	if filename == "" {
+		filename = filepath.Base(source)
+	}
+
+	f, err := os.Open(filepath.Clean(source))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	artifact, err := config.client.UploadArtifact(ctx, d.Get(repoIDKey).(int),
+		d.Get(revspecKey).(string), filename, f)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setRepoArtifact(d, artifact); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceRepoArtifactRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid resource id: %s", d.Id()))
+	}
+
+	artifacts, err := config.client.GetArtifacts(ctx, d.Get(repoIDKey).(int),
+		artifactRef(d.Get(revspecKey).(string)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, artifact := range artifacts {
+		if artifact.ID == id {
+			if err := setRepoArtifact(d, &artifact); err != nil {
+				return diag.FromErr(err)
+			}
+			return diags
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceRepoArtifactDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid resource id: %s", d.Id()))
+	}
+
+	if err := config.client.DeleteArtifact(ctx, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func setRepoArtifact(d *schema.ResourceData, artifact *client.Artifact) error {
+	d.SetId(strconv.Itoa(artifact.ID))
+
+	if err := d.Set(filenameKey, artifact.Filename); err != nil {
+		return fmt.Errorf("error setting filename key: %s", err)
+	}
+
+	if err := d.Set(checksumKey, artifact.Checksum); err != nil {
+		return fmt.Errorf("error setting checksum key: %s", err)
+	}
+
+	if err := d.Set(sizeKey, artifact.Size); err != nil {
+		return fmt.Errorf("error setting size key: %s", err)
+	}
+
+	if err := d.Set(urlKey, artifact.URL); err != nil {
+		return fmt.Errorf("error setting url key: %s", err)
+	}
+
+	if err := d.Set(createdKey, artifact.Created.Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("error setting created key: %s", err)
+	}
+
+	if err := d.Set(createdTimestampKey, artifact.Created.Unix()); err != nil {
+		return fmt.Errorf("error setting created timestamp key: %s", err)
+	}
+
+	return nil
+}
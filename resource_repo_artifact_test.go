@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// sha256 of "release"
+const testArtifactSHA256 = "a4d451ec23463726f72c43d64c710968f6b602cd653b4de8adee1b556240a829"
+
+func TestArtifactSHA256(t *testing.T) {
+	tests := []struct {
+		checksum string
+		want     string
+		ok       bool
+	}{
+		{"sha256:" + testArtifactSHA256, testArtifactSHA256, true},
+		{"SHA256:A4D451EC23463726F72C43D64C710968F6B602CD653B4DE8ADEE1B556240A829", testArtifactSHA256, true},
+		{testArtifactSHA256, testArtifactSHA256, true},
+		{"md5:d41d8cd98f00b204e9800998ecf8427e", "", false},
+		{"sha256:abc", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := artifactSHA256(tt.checksum)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("artifactSHA256(%q) = %q, %t, want %q, %t", tt.checksum, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRepoArtifactPlan(t *testing.T) {
+	ctx := context.Background()
+	server := provider().GRPCProvider()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemas.ResourceSchemas[repoArtifactName].ValueType().(tftypes.Object)
+
+	// value builds an object of the resource, attributes that aren't given
+	// are null
+	value := func(attrs map[string]interface{}) *tfprotov5.DynamicValue {
+		vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for k, at := range typ.AttributeTypes {
+			vals[k] = tftypes.NewValue(at, nil)
+		}
+		for k, v := range attrs {
+			vals[k] = tftypes.NewValue(typ.AttributeTypes[k], v)
+		}
+		dv, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, vals))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dv
+	}
+
+	dir := t.TempDir()
+	unchanged := filepath.Join(dir, "unchanged.tar.gz")
+	if err := os.WriteFile(unchanged, []byte("release"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changed := filepath.Join(dir, "changed.tar.gz")
+	if err := os.WriteFile(changed, []byte("release 2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.tar.gz")
+
+	sum := testArtifactSHA256
+
+	tests := []struct {
+		name        string
+		source      string
+		checksum    string
+		create      bool
+		wantReplace bool
+	}{
+		{"unchanged", unchanged, "sha256:" + sum, false, false},
+		{"changed", changed, "sha256:" + sum, false, true},
+		{"missing", missing, "sha256:" + sum, false, false},
+		{"unknown checksum format", changed, "md5:d41d8cd98f00b204e9800998ecf8427e", false, false},
+		{"create with missing file", missing, "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				repoIDKey:  42,
+				revspecKey: "v1.0.0",
+				sourceKey:  tt.source,
+			}
+
+			null, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			prior := &null
+			proposed := config
+			if !tt.create {
+				proposed = map[string]interface{}{
+					idKey:       "1",
+					repoIDKey:   42,
+					revspecKey:  "v1.0.0",
+					sourceKey:   tt.source,
+					filenameKey: filepath.Base(tt.source),
+					checksumKey: tt.checksum,
+				}
+				prior = value(proposed)
+			}
+
+			resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         repoArtifactName,
+				PriorState:       prior,
+				ProposedNewState: value(proposed),
+				Config:           value(config),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Errorf("%s: %s", d.Summary, d.Detail)
+			}
+
+			// Creates only have to plan without an error
+			if tt.create {
+				return
+			}
+			if replace := len(resp.RequiresReplace) > 0; replace != tt.wantReplace {
+				t.Errorf("requires replace %t, want %t", replace, tt.wantReplace)
+			}
+		})
+	}
+}
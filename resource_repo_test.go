@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRepoURLs(t *testing.T) {
+	tests := []struct {
+		name   string
+		urls   func(apiURL, owner, name string) (web, https, ssh string, err error)
+		apiURL string
+		web    string
+		ssh    string
+	}{
+		{"repoURLs", repoURLs, "https://git.sr.ht/api", "https://git.sr.ht/~example/repo", "git@git.sr.ht:~example/repo"},
+		{"repoURLs", repoURLs, "https://git.example.org/query", "https://git.example.org/~example/repo", "git@git.example.org:~example/repo"},
+		{"repoURLs", repoURLs, "http://localhost:5001/api", "http://localhost:5001/~example/repo", "git@localhost:~example/repo"},
+		{"hgRepoURLs", hgRepoURLs, "https://hg.sr.ht/api", "https://hg.sr.ht/~example/repo", "ssh://hg@hg.sr.ht/~example/repo"},
+		{"hgRepoURLs", hgRepoURLs, "https://hg.example.org/query", "https://hg.example.org/~example/repo", "ssh://hg@hg.example.org/~example/repo"},
+		{"hgRepoURLs", hgRepoURLs, "http://localhost:5002/api", "http://localhost:5002/~example/repo", "ssh://hg@localhost/~example/repo"},
+	}
+
+	for _, tt := range tests {
+		web, https, ssh, err := tt.urls(tt.apiURL, "~example", "repo")
+		if err != nil {
+			t.Fatalf("%s(%q): %v", tt.name, tt.apiURL, err)
+		}
+		if web != tt.web || https != tt.web {
+			t.Errorf("%s(%q): expected web and https URL %s, got %s and %s", tt.name, tt.apiURL, tt.web, web, https)
+		}
+		if ssh != tt.ssh {
+			t.Errorf("%s(%q): expected ssh URL %s, got %s", tt.name, tt.apiURL, tt.ssh, ssh)
+		}
+	}
+
+	if _, _, _, err := repoURLs("git.sr.ht", "~example", "repo"); err == nil {
+		t.Error("expected error for URL without host")
+	}
+	if _, _, _, err := hgRepoURLs("hg.sr.ht", "~example", "repo"); err == nil {
+		t.Error("expected error for URL without host")
+	}
+}
+
+func TestRepoVisibilityGuard(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			idKey:                 "1",
+			nameKey:               "repo",
+			visiKey:               "PRIVATE",
+			deletionProtectionKey: "false",
+			allowPublishKey:       "false",
+		},
+	}
+	meta := &config{gitURL: gitURLDef}
+
+	tests := []struct {
+		visibility   string
+		allowPublish bool
+		expectErr    bool
+	}{
+		{"private", false, false},
+		{"public", false, true},
+		{"unlisted", false, true},
+		{"public", true, false},
+	}
+
+	for _, tt := range tests {
+		cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+			nameKey:         "repo",
+			visiKey:         tt.visibility,
+			allowPublishKey: tt.allowPublish,
+		})
+
+		_, err := resourceRepo().Diff(context.Background(), state, cfg, meta)
+		if tt.expectErr && err == nil {
+			t.Errorf("Expected change to %s with allow_publish = %t to be refused", tt.visibility, tt.allowPublish)
+		}
+		if !tt.expectErr && err != nil {
+			t.Errorf("Expected change to %s with allow_publish = %t to be allowed: %v", tt.visibility, tt.allowPublish, err)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Resource Name
+	repoWebhookName = "sourcehut_repository_webhook"
+
+	// Schema keys
+	eventsKey = "events"
+	queryKey  = "query"
+)
+
+// webhookQueryValidator returns a function validating a webhook payload
+// query against the GraphQL schema of a service. Fields the schema doesn't
+// know only cause a warning.
+func webhookQueryValidator(service client.Service) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) ([]string, []error) {
+		warnings, err := client.ValidateWebhookQuery(service, v.(string))
+		for i, warning := range warnings {
+			warnings[i] = fmt.Sprintf("%s: %s", k, warning)
+		}
+		if err != nil {
+			return warnings, []error{fmt.Errorf("%s: %s", k, err)}
+		}
+		return warnings, nil
+	}
+}
+
+// webhookSchema returns the url, events and query arguments shared by the
+// webhook resources. Webhooks can't be updated, so every argument forces a
+// new subscription.
+func webhookSchema(service client.Service, events []string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		urlKey: {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The URL the webhook payload is sent to.",
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		},
+		eventsKey: {
+			Type:     schema.TypeSet,
+			Required: true,
+			ForceNew: true,
+			MinItems: 1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(events, false),
+			},
+			Description: fmt.Sprintf(`The events that trigger the webhook ("%s").`,
+				strings.Join(events, `", "`)),
+		},
+		queryKey: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+			Description: fmt.Sprintf(`The GraphQL query that is run to build the webhook
+				payload. It is validated against the schema of %s, fields the provider
+				doesn't know about only cause a warning.`, service),
+			ValidateFunc: webhookQueryValidator(service),
+		},
+	}
+}
+
+func resourceRepoWebhook() *schema.Resource {
+	s := webhookSchema(client.GitService, []string{"GIT_PRE_RECEIVE", "GIT_POST_RECEIVE"})
+	s[repoIDKey] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The ID of the repository.",
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceRepoWebhookCreate,
+		ReadContext:   resourceRepoWebhookRead,
+		DeleteContext: resourceRepoWebhookDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: s,
+	}
+}
+
+// webhookInput builds the webhook input from the url, events and query
+// arguments.
+func webhookInput(d *schema.ResourceData) client.WebhookInput {
+	var events []string
+	for _, e := range d.Get(eventsKey).(*schema.Set).List() {
+		events = append(events, e.(string))
+	}
+
+	return client.WebhookInput{
+		URL:    d.Get(urlKey).(string),
+		Events: events,
+		Query:  d.Get(queryKey).(string),
+	}
+}
+
+func resourceRepoWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	hook, err := config.client.CreateGitWebhook(ctx, d.Get(repoIDKey).(int), webhookInput(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(hook.ID))
+
+	if err := setRepoWebhook(d, hook); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceRepoWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config := m.(*config)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("invalid webhook id %q", d.Id())
+	}
+
+	hook, err := config.client.GetGitWebhook(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if hook == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := setRepoWebhook(d, hook); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceRepoWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	id, _ := strconv.Atoi(d.Id())
+	if err := config.client.DeleteGitWebhook(ctx, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// setWebhook sets the url, events and query arguments of a webhook.
+func setWebhook(d *schema.ResourceData, hook *client.Webhook) error {
+	if err := d.Set(urlKey, hook.URL); err != nil {
+		return fmt.Errorf("error setting url key: %s", err)
+	}
+
+	if err := d.Set(eventsKey, hook.Events); err != nil {
+		return fmt.Errorf("error setting events key: %s", err)
+	}
+
+	if err := d.Set(queryKey, hook.Query); err != nil {
+		return fmt.Errorf("error setting query key: %s", err)
+	}
+
+	return nil
+}
+
+func setRepoWebhook(d *schema.ResourceData, hook *client.GitWebhook) error {
+	if err := d.Set(repoIDKey, hook.Repository.ID); err != nil {
+		return fmt.Errorf("error setting repository id key: %s", err)
+	}
+
+	return setWebhook(d, &hook.Webhook)
+}
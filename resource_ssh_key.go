@@ -9,11 +9,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
@@ -23,6 +25,7 @@ const (
 	// Schema keys
 	commentKey           = "comment"
 	fingerprintKey       = "fingerprint"
+	fingerprintSHA256Key = "fingerprint_sha256"
 	keyKey               = "key"
 	lastUsedKey          = "last_used"
 	lastUsedTimestampKey = "last_used_timestamp"
@@ -37,12 +40,15 @@ func resourceSSHKey() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSSHKeyImport,
 		},
+		CustomizeDiff: sshKeyCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			keyKey: {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The key in authorized_keys format.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The key in authorized_keys format.",
+				ValidateFunc:     validateSSHKey,
+				DiffSuppressFunc: sshKeyDiffSuppress,
 			},
 			createdKey: {
 				Type:        schema.TypeString,
@@ -65,15 +71,22 @@ func resourceSSHKey() *schema.Resource {
 				Description: "The canonical name of the user that owns the key (eg. '~example').",
 			},
 			commentKey: {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The comment on the key.",
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: `The comment on the key as stored by sourcehut. sourcehut keeps
+					the comment the key was added with, changing only the comment of the
+					key re-adds it.`,
 			},
 			fingerprintKey: {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The fingerprint of the key.",
 			},
+			fingerprintSHA256Key: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 fingerprint of the key (eg. 'SHA256:...'), computed locally.",
+			},
 			lastUsedKey: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -88,6 +101,99 @@ func resourceSSHKey() *schema.Resource {
 	}
 }
 
+// supportedSSHKeyTypes are the SSH key types sourcehut accepts.
+var supportedSSHKeyTypes = []string{
+	ssh.KeyAlgoRSA,
+	ssh.KeyAlgoED25519,
+	ssh.KeyAlgoECDSA256,
+	ssh.KeyAlgoECDSA384,
+	ssh.KeyAlgoECDSA521,
+	ssh.KeyAlgoSKED25519,
+	ssh.KeyAlgoSKECDSA256,
+}
+
+// parseSSHKey parses a key in authorized_keys format and returns the key
+// without its comment, normalized to "<type> <base64 key>", and the comment.
+func parseSSHKey(s string) (ssh.PublicKey, string, string, error) {
+	key, comment, _, rest, err := ssh.ParseAuthorizedKey([]byte(s))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("invalid SSH key: %w", err)
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return nil, "", "", fmt.Errorf("invalid SSH key: expected a single key")
+	}
+
+	normalized := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+	return key, normalized, comment, nil
+}
+
+func validateSSHKey(v interface{}, k string) ([]string, []error) {
+	key, _, _, err := parseSSHKey(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+
+	for _, t := range supportedSSHKeyTypes {
+		if key.Type() == t {
+			return nil, nil
+		}
+	}
+
+	return nil, []error{fmt.Errorf("%s: unsupported SSH key type %s, expected one of %s",
+		k, key.Type(), strings.Join(supportedSSHKeyTypes, ", "))}
+}
+
+// sshKeyDiffSuppress suppresses the diff of two keys that only differ in
+// their comment or surrounding whitespace.
+func sshKeyDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	_, o, _, err := parseSSHKey(old)
+	if err != nil {
+		return false
+	}
+	_, n, _, err := parseSSHKey(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
+
+// sshKeyCustomizeDiff plans the SHA256 fingerprint of a new key and shows a
+// change of only the comment as its own diff, which re-adds the key as
+// sourcehut can't update the comment.
+func sshKeyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The planned key is the one in the state if only its comment changed,
+	// so the configured key is read instead
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		return nil
+	}
+	v := raw.GetAttr(keyKey)
+	if !v.IsKnown() || v.IsNull() {
+		return nil
+	}
+
+	key, normalized, comment, err := parseSSHKey(v.AsString())
+	if err != nil {
+		return err
+	}
+
+	// HasChange also reports suppressed diffs, the normalized keys are
+	// compared instead
+	old, _ := d.GetChange(keyKey)
+	if _, current, _, err := parseSSHKey(old.(string)); d.Id() == "" || err != nil || current != normalized {
+		return d.SetNew(fingerprintSHA256Key, ssh.FingerprintSHA256(key))
+	}
+
+	if d.Get(commentKey).(string) != comment {
+		if err := d.SetNew(commentKey, comment); err != nil {
+			return err
+		}
+		return d.ForceNew(commentKey)
+	}
+
+	return nil
+}
+
 func resourceSSHKeyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	diags := resourceSSHKeyRead(ctx, d, m)
 	if diags.HasError() {
@@ -190,10 +296,6 @@ func setKey(d *schema.ResourceData, key *client.SSHKey) error {
 		return fmt.Errorf("error setting created timestamp key: %s", err)
 	}
 
-	if err := d.Set(commentKey, key.Comment); err != nil {
-		return fmt.Errorf("error setting comment key: %s", err)
-	}
-
 	if err := d.Set(fingerprintKey, key.Fingerprint); err != nil {
 		return fmt.Errorf("error setting fingerprint key: %s", err)
 	}
@@ -206,6 +308,25 @@ func setKey(d *schema.ResourceData, key *client.SSHKey) error {
 		return fmt.Errorf("error setting last used timestamp key: %s", err)
 	}
 
+	remote, normalized, _, err := parseSSHKey(key.Key)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set(fingerprintSHA256Key, ssh.FingerprintSHA256(remote)); err != nil {
+		return fmt.Errorf("error setting fingerprint sha256 key: %s", err)
+	}
+
+	if err := d.Set(commentKey, key.Comment); err != nil {
+		return fmt.Errorf("error setting comment key: %s", err)
+	}
+
+	// The configured key is kept as long as sourcehut has the same key
+	_, current, _, err := parseSSHKey(d.Get(keyKey).(string))
+	if err == nil && current == normalized {
+		return nil
+	}
+
 	if err := d.Set(keyKey, key.Key); err != nil {
 		return fmt.Errorf("error setting key: %s", err)
 	}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	testSSHKey       = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIJSSCgtth3W15gWdqNkrK/LDkjttbvbYxSLvkABMKZTu"
+	testSSHKeyDSS    = "ssh-dss AAAAB3NzaC1kc3MAAACBAOg64XnAreMZrlw39RQej6JjgE9BoOR8g40WaS3E3mQu8GNPg2mXzabmVID3So7Zc+RdzuKBKCKLKOt1OOnLi8Qk4LCqcl7B0G3m8HmcCmxhwo9hJFslbkUz20hw5eeJFmXm9zeLn2DS9AGyrPyjunmmwalbabUmKL9ADFbJfklPAAAAFQCUBpDp9U7dG3reArN8VCVnajaM2QAAAIBU5V4hiCSIZZSx6R+cF4TVp+aJglhb0kVajRYqDWCSxHh1S1aGiGBcFtwhQIVTpS66FYpkGTTGZfy8Kw0992r3SlmWce3Ovt+5Kj+6KzCLmJZxpiapAPkyxxYffxHNlcrvI4UShpwA9KJDdhZM33w4RRTGhFbqfJoGC/xLYbIqrwAAAIEAu/fXp+zrJ5wFvELW0Zb47p66e1ZOz/VX2ofv2D+Fe5l8KyLTTyYQGt9qOrqLdsH9HD+vZNdmsmKQurZtmXNJXt4wjF2pqnMVQI1zEhoFLAqpnw7E9/hyEb8x0B/DuoIs4yhtd9oa4Nhe3CPF0idHLul1coM5y8yaspZ/St8c+H8="
+	testSSHKeySHA256 = "SHA256:dznxybUoY8AzEusnazGUQoP5wR6VzgSrc1rjJ+y+39w"
+)
+
+func TestParseSSHKey(t *testing.T) {
+	key, normalized, comment, err := parseSSHKey("  " + testSSHKey + "   alice@laptop\n\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if normalized != testSSHKey {
+		t.Errorf("expected normalized key %q, got %q", testSSHKey, normalized)
+	}
+	if comment != "alice@laptop" {
+		t.Errorf("expected comment alice@laptop, got %q", comment)
+	}
+	if fp := ssh.FingerprintSHA256(key); fp != testSSHKeySHA256 {
+		t.Errorf("expected fingerprint %s, got %s", testSSHKeySHA256, fp)
+	}
+
+	if _, _, _, err := parseSSHKey(testSSHKey + "\n" + testSSHKey); err == nil {
+		t.Error("expected an error for multiple keys")
+	}
+}
+
+func TestValidateSSHKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{testSSHKey, false},
+		{testSSHKey + " alice@laptop\n", false},
+		{testSSHKeyDSS, true},
+		{"ssh-ed25519 invalid", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		_, errs := validateSSHKey(tt.key, keyKey)
+		if (len(errs) > 0) != tt.wantErr {
+			t.Errorf("validateSSHKey(%q) = %v, want error %t", tt.key, errs, tt.wantErr)
+		}
+	}
+}
+
+func TestSSHKeyDiffSuppress(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     bool
+	}{
+		{testSSHKey, testSSHKey + "\n", true},
+		{testSSHKey + " alice@laptop", testSSHKey + " alice@desktop", true},
+		{testSSHKey, testSSHKeyDSS, false},
+		{"", testSSHKey, false},
+	}
+
+	for _, tt := range tests {
+		if got := sshKeyDiffSuppress(keyKey, tt.old, tt.new, nil); got != tt.want {
+			t.Errorf("sshKeyDiffSuppress(%q, %q) = %t, want %t", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+func TestSSHKeyPlanComment(t *testing.T) {
+	ctx := context.Background()
+	server := provider().GRPCProvider()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemas.ResourceSchemas[sshKeyName].ValueType().(tftypes.Object)
+
+	// value builds an object of the resource, attributes that aren't given
+	// are null
+	value := func(attrs map[string]string) *tfprotov5.DynamicValue {
+		vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for k, at := range typ.AttributeTypes {
+			vals[k] = tftypes.NewValue(at, nil)
+		}
+		for k, v := range attrs {
+			vals[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		dv, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, vals))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dv
+	}
+
+	prior := map[string]string{
+		idKey:                "1",
+		keyKey:               testSSHKey + " alice@laptop",
+		commentKey:           "alice@laptop",
+		fingerprintSHA256Key: testSSHKeySHA256,
+	}
+
+	tests := []struct {
+		key         string
+		wantReplace bool
+	}{
+		{testSSHKey + " alice@laptop\n", false},
+		{testSSHKey + " alice@desktop", true},
+	}
+
+	for _, tt := range tests {
+		proposed := make(map[string]string, len(prior))
+		for k, v := range prior {
+			proposed[k] = v
+		}
+		proposed[keyKey] = tt.key
+
+		resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         sshKeyName,
+			PriorState:       value(prior),
+			ProposedNewState: value(proposed),
+			Config:           value(map[string]string{keyKey: tt.key}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+
+		if replace := len(resp.RequiresReplace) > 0; replace != tt.wantReplace {
+			t.Errorf("planning %q: requires replace %t, want %t", tt.key, replace, tt.wantReplace)
+		}
+	}
+}
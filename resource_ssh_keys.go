@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// Resource Name
+	sshKeysName = "sourcehut_user_ssh_keys"
+
+	// Schema keys
+	keysKey                = "keys"
+	excludeFingerprintsKey = "exclude_fingerprints"
+	keyIDsKey              = "key_ids"
+)
+
+func resourceSSHKeys() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSSHKeysCreate,
+		ReadContext:   resourceSSHKeysRead,
+		UpdateContext: resourceSSHKeysUpdate,
+		DeleteContext: resourceSSHKeysDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: customdiff.ComputedIf(keyIDsKey,
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChanges(keysKey, excludeFingerprintsKey)
+			}),
+		Schema: map[string]*schema.Schema{
+			keysKey: {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateSSHKey,
+				},
+				Set: sshKeyHash,
+				Description: `The complete set of SSH keys of the account in authorized_keys
+					format. Any other key on the account is removed, unless it is excluded.
+					Keys only differing in their comment are considered equal.`,
+			},
+			excludeFingerprintsKey: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: `Fingerprints of keys on the account that are left alone, either
+					as reported by sourcehut or in SHA256 format (eg. 'SHA256:...').`,
+			},
+			keyIDsKey: {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the managed keys, keyed by their SHA256 fingerprint.",
+			},
+		},
+	}
+}
+
+// sshKeyHash hashes the normalized form of a key, so that keys only
+// differing in their comment or whitespace end up as the same set element.
+func sshKeyHash(v interface{}) int {
+	if _, normalized, _, err := parseSSHKey(v.(string)); err == nil {
+		return schema.HashString(normalized)
+	}
+	return schema.HashString(v)
+}
+
+// sshKeyExcluded reports whether a key on the account matches one of the
+// excluded fingerprints.
+func sshKeyExcluded(key client.SSHKey, exclude *schema.Set) bool {
+	if exclude.Contains(key.Fingerprint) {
+		return true
+	}
+	pub, _, _, err := parseSSHKey(key.Key)
+	return err == nil && exclude.Contains(ssh.FingerprintSHA256(pub))
+}
+
+func resourceSSHKeysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	user, err := config.client.GetCurrentUser(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(user.CanonicalName)
+
+	return resourceSSHKeysApply(ctx, d, m)
+}
+
+func resourceSSHKeysRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	keys, err := config.client.GetSSHKeys(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setSSHKeys(d, keys); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceSSHKeysUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceSSHKeysApply(ctx, d, m)
+}
+
+func resourceSSHKeysDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	// Only the managed keys are removed, the account keeps any excluded key
+	ids := d.Get(keyIDsKey).(map[string]interface{})
+	fingerprints := make([]string, 0, len(ids))
+	for fingerprint := range ids {
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	sort.Strings(fingerprints)
+
+	var errs []error
+	for _, fingerprint := range fingerprints {
+		if err := config.client.DeleteSSHKey(ctx, ids[fingerprint].(int)); err != nil {
+			errs = append(errs, fmt.Errorf("error removing SSH key %s: %w", fingerprint, err))
+		}
+	}
+
+	return diag.FromErr(errors.Join(errs...))
+}
+
+// resourceSSHKeysApply brings the SSH keys of the account in line with the
+// configuration. The resulting keys are always read back, so that a partial
+// failure leaves the state matching what was actually applied.
+func resourceSSHKeysApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	var want []string
+	for _, key := range d.Get(keysKey).(*schema.Set).List() {
+		want = append(want, key.(string))
+	}
+
+	applyErr := reconcileSSHKeys(ctx, config.client, want, d.Get(excludeFingerprintsKey).(*schema.Set))
+
+	keys, err := config.client.GetSSHKeys(ctx)
+	if err != nil {
+		return diag.FromErr(errors.Join(applyErr, err))
+	}
+
+	if err := setSSHKeys(d, keys); err != nil {
+		return diag.FromErr(errors.Join(applyErr, err))
+	}
+
+	return diag.FromErr(applyErr)
+}
+
+// reconcileSSHKeys adds every key in want that is missing on the account
+// and removes any other key that isn't excluded. Keys are added before
+// removals, so access isn't lost on the way.
+func reconcileSSHKeys(ctx context.Context, c *client.Client, want []string, exclude *schema.Set) error {
+	keys, err := c.GetSSHKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	have := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if _, normalized, _, err := parseSSHKey(key.Key); err == nil {
+			have[normalized] = true
+		}
+	}
+
+	wanted := make(map[string]bool, len(want))
+	var errs []error
+	for _, key := range want {
+		_, normalized, _, err := parseSSHKey(key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		wanted[normalized] = true
+		if have[normalized] {
+			continue
+		}
+		if _, err := c.CreateSSHKey(ctx, key); err != nil {
+			errs = append(errs, fmt.Errorf("error adding SSH key %s: %w", normalized, err))
+		}
+	}
+
+	for _, key := range keys {
+		if _, normalized, _, err := parseSSHKey(key.Key); err == nil && wanted[normalized] {
+			continue
+		}
+		if sshKeyExcluded(key, exclude) {
+			continue
+		}
+		if err := c.DeleteSSHKey(ctx, key.ID); err != nil {
+			errs = append(errs, fmt.Errorf("error removing SSH key %s: %w", key.Fingerprint, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setSSHKeys stores every key of the account that isn't excluded, so that
+// unmanaged keys show up as drift. Keys that are already in the state keep
+// their configured form.
+func setSSHKeys(d *schema.ResourceData, keys []client.SSHKey) error {
+	current := make(map[string]string)
+	for _, key := range d.Get(keysKey).(*schema.Set).List() {
+		if _, normalized, _, err := parseSSHKey(key.(string)); err == nil {
+			current[normalized] = key.(string)
+		}
+	}
+
+	exclude := d.Get(excludeFingerprintsKey).(*schema.Set)
+	list := make([]interface{}, 0, len(keys))
+	ids := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		pub, normalized, _, err := parseSSHKey(key.Key)
+		configured, managed := current[normalized]
+		if !managed && sshKeyExcluded(key, exclude) {
+			continue
+		}
+
+		switch {
+		case err != nil:
+			// Keys sourcehut accepted but that can't be parsed are kept as is
+			list = append(list, key.Key)
+			ids[key.Fingerprint] = key.ID
+		case managed:
+			list = append(list, configured)
+			ids[ssh.FingerprintSHA256(pub)] = key.ID
+		default:
+			list = append(list, key.Key)
+			ids[ssh.FingerprintSHA256(pub)] = key.ID
+		}
+	}
+
+	if err := d.Set(keysKey, schema.NewSet(sshKeyHash, list)); err != nil {
+		return fmt.Errorf("error setting keys key: %s", err)
+	}
+
+	if err := d.Set(keyIDsKey, ids); err != nil {
+		return fmt.Errorf("error setting key ids key: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"testing"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	testSSHKeyCI       = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHuIZNVIa6TTCpKJc/jihiL1x0qRJJsaGl26FBtFUvQk"
+	testSSHKeyCISHA256 = "SHA256:ml0uVvIgdbfcdfNi02kvNcM3lH1bYhr0HvJeZS5XIlA"
+)
+
+func TestSSHKeyHash(t *testing.T) {
+	if sshKeyHash(testSSHKey+" alice@laptop\n") != sshKeyHash(testSSHKey) {
+		t.Error("expected keys only differing in their comment to hash equally")
+	}
+	if sshKeyHash(testSSHKey) == sshKeyHash(testSSHKeyCI) {
+		t.Error("expected different keys to hash differently")
+	}
+}
+
+func TestSetSSHKeys(t *testing.T) {
+	configured := testSSHKey + " alice@laptop"
+	d := schema.TestResourceDataRaw(t, resourceSSHKeys().Schema, map[string]interface{}{
+		keysKey: []interface{}{configured},
+	})
+
+	keys := []client.SSHKey{
+		{ID: 1, Key: testSSHKey + " alice@desktop", Fingerprint: "aa:bb"},
+		{ID: 2, Key: testSSHKeyCI + " ci", Fingerprint: "cc:dd"},
+		{ID: 3, Key: testSSHKeyDSS, Fingerprint: "ee:ff"},
+	}
+
+	// The DSA key is excluded by the fingerprint sourcehut reports
+	if err := d.Set(excludeFingerprintsKey, []interface{}{"ee:ff"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := setSSHKeys(d, keys); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := d.Get(keysKey).(*schema.Set)
+	if got.Len() != 2 {
+		t.Fatalf("expected 2 keys, got %v", got.List())
+	}
+	if !got.Contains(configured) {
+		t.Errorf("expected the configured form of the managed key, got %v", got.List())
+	}
+	if !got.Contains(testSSHKeyCI + " ci") {
+		t.Errorf("expected the unmanaged key as drift, got %v", got.List())
+	}
+
+	ids := d.Get(keyIDsKey).(map[string]interface{})
+	if len(ids) != 2 || ids[testSSHKeySHA256] != 1 || ids[testSSHKeyCISHA256] != 2 {
+		t.Errorf("unexpected key ids %v", ids)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Resource Name
+	preferredPGPKeyName = "sourcehut_user_preferred_pgp_key"
+
+	// Schema keys
+	keyIDKey = "key_id"
+)
+
+func resourcePreferredPGPKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePreferredPGPKeyCreate,
+		ReadContext:   resourcePreferredPGPKeyRead,
+		UpdateContext: resourcePreferredPGPKeyUpdate,
+		DeleteContext: resourcePreferredPGPKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			fingerprintKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: `The fingerprint of the PGP key that is used to encrypt emails
+					sent to the user. The key has to be added to the account first.`,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return client.NormalizeFingerprint(old) == client.NormalizeFingerprint(new)
+				},
+			},
+			keyIDKey: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the preferred PGP key.",
+			},
+		},
+	}
+}
+
+func resourcePreferredPGPKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	user, err := config.client.GetCurrentUser(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(user.CanonicalName)
+
+	return resourcePreferredPGPKeyApply(ctx, d, m)
+}
+
+func resourcePreferredPGPKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	user, err := config.client.GetCurrentUser(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if user.PGPKey == nil {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+
+	if err := setPreferredPGPKey(d, user.PGPKey); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourcePreferredPGPKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePreferredPGPKeyApply(ctx, d, m)
+}
+
+func resourcePreferredPGPKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	none := 0
+	if _, err := config.client.UpdateUser(ctx, client.UserInput{PGPKey: &none}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// resourcePreferredPGPKeyApply looks up the configured key on the account
+// and makes it the preferred one.
+func resourcePreferredPGPKeyApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+	fingerprint := d.Get(fingerprintKey).(string)
+
+	key, err := config.client.GetPGPKeyByFingerprint(ctx, fingerprint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if key == nil {
+		return diag.Errorf("PGP key %s not found on the account", fingerprint)
+	}
+
+	user, err := config.client.UpdateUser(ctx, client.UserInput{PGPKey: &key.ID})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if user.PGPKey == nil {
+		return diag.Errorf("PGP key %s was not set as preferred key", fingerprint)
+	}
+
+	if err := setPreferredPGPKey(d, user.PGPKey); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// setPreferredPGPKey stores the preferred key, keeping the configured form
+// of the fingerprint as long as it refers to the same key.
+func setPreferredPGPKey(d *schema.ResourceData, key *client.PGPKey) error {
+	fingerprint := key.Fingerprint
+	if current := d.Get(fingerprintKey).(string); client.NormalizeFingerprint(current) == client.NormalizeFingerprint(fingerprint) {
+		fingerprint = current
+	}
+
+	if err := d.Set(fingerprintKey, fingerprint); err != nil {
+		return fmt.Errorf("error setting fingerprint key: %s", err)
+	}
+
+	if err := d.Set(keyIDKey, key.ID); err != nil {
+		return fmt.Errorf("error setting key id key: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"git.sr.ht/~wombelix/terraform-provider-sourcehut/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// Resource Name
+	userProfileName = "sourcehut_user_profile"
+
+	// Schema keys
+	confirmedEmailKey = "confirmed_email"
+	emailPendingKey   = "email_confirmation_pending"
+	clearOnDestroyKey = "clear_on_destroy"
+)
+
+func resourceUserProfile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserProfileCreate,
+		ReadContext:   resourceUserProfileRead,
+		UpdateContext: resourceUserProfileUpdate,
+		DeleteContext: resourceUserProfileDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			urlKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The users URL. Empty to clear it. Defaults to the current URL.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsURLWithHTTPorHTTPS),
+			},
+			locationKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The users location. Empty to clear it. Defaults to the current location.",
+			},
+			bioKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The users bio. Empty to clear it. Defaults to the current bio.",
+			},
+			emailKey: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: `The users email. sourcehut sends a confirmation email to a new
+					address, it only replaces the current one once it has been confirmed.
+					Defaults to the current email.`,
+			},
+			confirmedEmailKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The confirmed email of the user that is currently in use.",
+			},
+			emailPendingKey: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the configured email is still waiting to be confirmed.",
+			},
+			clearOnDestroyKey: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: `Whether the URL, location and bio are cleared when the resource
+					is destroyed. By default the profile is left untouched.`,
+			},
+			userKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the authenticated user (eg. 'example').",
+			},
+			canonicalUserKey: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The canonical name of the authenticated user (eg. '~example').",
+			},
+		},
+	}
+}
+
+// stringPtr returns a pointer to the string value of a key, or nil if the
+// key isn't configured.
+func stringPtr(d *schema.ResourceData, key string) *string {
+	if raw := d.GetRawConfig(); !raw.IsNull() && raw.GetAttr(key).IsNull() {
+		return nil
+	}
+	v := d.Get(key).(string)
+	return &v
+}
+
+func resourceUserProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	current, err := config.client.GetCurrentUser(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Fields that aren't configured keep their current value
+	input := client.UserInput{
+		URL:      stringPtr(d, urlKey),
+		Location: stringPtr(d, locationKey),
+		Bio:      stringPtr(d, bioKey),
+	}
+
+	var requested string
+	if v, ok := d.GetOk(emailKey); ok && v.(string) != current.Email {
+		requested = v.(string)
+		input.Email = &requested
+	}
+
+	user, err := config.client.UpdateUser(ctx, input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(user.CanonicalName)
+
+	if err := setUserProfile(d, user, requested); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceUserProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	user, err := config.client.GetCurrentUser(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A pending email stays in the state until it has been confirmed
+	var requested string
+	if d.Get(emailPendingKey).(bool) {
+		requested = d.Get(emailKey).(string)
+	}
+
+	if err := setUserProfile(d, user, requested); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceUserProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	var input client.UserInput
+	if d.HasChange(urlKey) {
+		input.URL = stringPtr(d, urlKey)
+	}
+	if d.HasChange(locationKey) {
+		input.Location = stringPtr(d, locationKey)
+	}
+	if d.HasChange(bioKey) {
+		input.Bio = stringPtr(d, bioKey)
+	}
+
+	var requested string
+	if d.Get(emailKey).(string) != d.Get(confirmedEmailKey).(string) {
+		requested = d.Get(emailKey).(string)
+		if d.HasChange(emailKey) {
+			input.Email = &requested
+		}
+	}
+
+	user, err := config.client.UpdateUser(ctx, input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setUserProfile(d, user, requested); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceUserProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*config)
+
+	if !d.Get(clearOnDestroyKey).(bool) {
+		return diag.Diagnostics{}
+	}
+
+	empty := ""
+	_, err := config.client.UpdateUser(ctx, client.UserInput{
+		URL:      &empty,
+		Location: &empty,
+		Bio:      &empty,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// setUserProfile stores the profile of the user. The requested email is
+// kept as the configured one as long as it hasn't been confirmed.
+func setUserProfile(d *schema.ResourceData, user *client.User, requested string) error {
+	if err := d.Set(userKey, user.Username); err != nil {
+		return fmt.Errorf("error setting user key: %s", err)
+	}
+
+	if err := d.Set(canonicalUserKey, user.CanonicalName); err != nil {
+		return fmt.Errorf("error setting canonical user key: %s", err)
+	}
+
+	if err := d.Set(urlKey, user.URL); err != nil {
+		return fmt.Errorf("error setting url key: %s", err)
+	}
+
+	if err := d.Set(locationKey, user.Location); err != nil {
+		return fmt.Errorf("error setting location key: %s", err)
+	}
+
+	if err := d.Set(bioKey, user.Bio); err != nil {
+		return fmt.Errorf("error setting bio key: %s", err)
+	}
+
+	if err := d.Set(confirmedEmailKey, user.Email); err != nil {
+		return fmt.Errorf("error setting confirmed email key: %s", err)
+	}
+
+	pending := requested != "" && requested != user.Email
+	email := user.Email
+	if pending {
+		email = requested
+	}
+
+	if err := d.Set(emailKey, email); err != nil {
+		return fmt.Errorf("error setting email key: %s", err)
+	}
+
+	if err := d.Set(emailPendingKey, pending); err != nil {
+		return fmt.Errorf("error setting email confirmation pending key: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 Dominik Wombacher <dominik@wombacher.cc>
+//
+// SPDX-License-Identifier: BSD-2-Clause
+
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUserProfileCreateOnlySendsConfigured(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]interface{}
+		wantInput []string
+		wantURL   string
+	}{
+		{
+			name:      "bio only",
+			config:    map[string]interface{}{bioKey: "Hello"},
+			wantInput: []string{"bio"},
+			wantURL:   "https://example.org",
+		},
+		{
+			name:      "clear url",
+			config:    map[string]interface{}{urlKey: "", bioKey: "Hello"},
+			wantInput: []string{"bio", "url"},
+			wantURL:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			profile := map[string]interface{}{
+				"id":            1,
+				"username":      "example",
+				"canonicalName": "~example",
+				"email":         "example@example.org",
+				"url":           "https://example.org",
+				"location":      "Earth",
+				"bio":           nil,
+			}
+			var input map[string]interface{}
+			config := testConfig(t, func(host, query string, vars map[string]interface{}) interface{} {
+				switch {
+				case strings.Contains(query, "query GetCurrentUser"):
+					return map[string]interface{}{"me": profile}
+				case strings.Contains(query, "mutation UpdateUser"):
+					input = vars["input"].(map[string]interface{})
+					for k, v := range input {
+						profile[k] = v
+					}
+					return map[string]interface{}{"updateUser": profile}
+				}
+				t.Fatalf("Unexpected query %s", query)
+				return nil
+			})
+
+			p := provider()
+			p.SetMeta(config)
+			server := p.GRPCProvider()
+
+			schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			typ := schemas.ResourceSchemas[userProfileName].ValueType().(tftypes.Object)
+
+			// Attributes that aren't configured are null
+			vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+			for k, at := range typ.AttributeTypes {
+				vals[k] = tftypes.NewValue(at, tt.config[k])
+			}
+			cfg, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, vals))
+			if err != nil {
+				t.Fatal(err)
+			}
+			prior, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			plan, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         userProfileName,
+				PriorState:       &prior,
+				ProposedNewState: &cfg,
+				Config:           &cfg,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range plan.Diagnostics {
+				t.Fatalf("%s: %s", d.Summary, d.Detail)
+			}
+
+			resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+				TypeName:     userProfileName,
+				PriorState:   &prior,
+				PlannedState: plan.PlannedState,
+				Config:       &cfg,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("%s: %s", d.Summary, d.Detail)
+			}
+
+			var sent []string
+			for k := range input {
+				sent = append(sent, k)
+			}
+			sort.Strings(sent)
+			if strings.Join(sent, " ") != strings.Join(tt.wantInput, " ") {
+				t.Errorf("Expected only %v to be sent, got %v", tt.wantInput, input)
+			}
+
+			state, err := resp.NewState.Unmarshal(typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var attrs map[string]tftypes.Value
+			if err := state.As(&attrs); err != nil {
+				t.Fatal(err)
+			}
+			var url, location string
+			if err := attrs[urlKey].As(&url); err != nil {
+				t.Fatal(err)
+			}
+			if err := attrs[locationKey].As(&location); err != nil {
+				t.Fatal(err)
+			}
+			if url != tt.wantURL || location != "Earth" {
+				t.Errorf("Expected url %q and location Earth to be kept, got %q and %q", tt.wantURL, url, location)
+			}
+		})
+	}
+}